@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// cacheEntryName matches files written by the yf-go file cache store: a hex
+// SHA-1 of the cache key plus ".json".
+var cacheEntryName = regexp.MustCompile(`^[0-9a-f]{40}\.json$`)
+
+// cacheEntry is one file of the persistent Yahoo cache.
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// listCacheEntries returns the cache entries directly under dir; other files
+// are ignored.
+func listCacheEntries(dir string) ([]cacheEntry, error) {
+	des, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var out []cacheEntry
+	for _, de := range des {
+		if de.IsDir() || !cacheEntryName.MatchString(de.Name()) {
+			continue
+		}
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		out = append(out, cacheEntry{path: filepath.Join(dir, de.Name()), size: info.Size(), modTime: info.ModTime()})
+	}
+	return out, nil
+}
+
+// newCacheCmd builds `wl cache`, which inspects the persistent Yahoo cache
+// resolved exactly like the root command (--cache-dir, then cache.dir).
+func newCacheCmd(configPath, configDir, cacheDirFlag *string) *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect or clear the persistent Yahoo Finance cache",
+	}
+	resolve := func(cmd *cobra.Command) (string, error) {
+		wlHome := resolveHome()
+		vp, err := loadViper(resolveConfigPath(*configPath, *configDir, wlHome))
+		if err != nil {
+			return "", err
+		}
+		dir := resolveCacheDir(cmd, vp.GetString("cache.dir"), *cacheDirFlag, wlHome)
+		if dir == "" {
+			return "", errors.New("no cache directory configured; pass --cache-dir or set cache.dir in config")
+		}
+		return dir, nil
+	}
+
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show entry count, size and age of the cache directory",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			dir, err := resolve(cmd)
+			if err != nil {
+				return err
+			}
+			entries, err := listCacheEntries(dir)
+			if err != nil {
+				return fmt.Errorf("read cache dir: %w", err)
+			}
+			var total int64
+			var oldest, newest time.Time
+			for _, e := range entries {
+				total += e.size
+				if oldest.IsZero() || e.modTime.Before(oldest) {
+					oldest = e.modTime
+				}
+				if e.modTime.After(newest) {
+					newest = e.modTime
+				}
+			}
+			stamp := func(t time.Time) string {
+				if t.IsZero() {
+					return "-"
+				}
+				return t.Local().Format("2006-01-02 15:04:05")
+			}
+			tw := table.NewWriter()
+			tw.SetOutputMirror(cmd.OutOrStdout())
+			tw.SetStyle(table.StyleLight)
+			tw.AppendRows([]table.Row{
+				{"dir", dir},
+				{"entries", len(entries)},
+				{"size", humanBytes(total)},
+				{"oldest", stamp(oldest)},
+				{"newest", stamp(newest)},
+			})
+			tw.Render()
+			return nil
+		},
+	}
+
+	var olderThan string
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Delete cache entries, optionally only those older than --older-than",
+		Long: "Delete cache entries from the cache directory. Only files named like yf-go\n" +
+			"cache entries (<sha1>.json) are removed; anything else in the directory is left alone.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			var maxAge time.Duration
+			if strings.TrimSpace(olderThan) != "" {
+				d, err := parseAge(olderThan)
+				if err != nil {
+					return fmt.Errorf("invalid --older-than: %w", err)
+				}
+				maxAge = d
+			}
+			dir, err := resolve(cmd)
+			if err != nil {
+				return err
+			}
+			entries, err := listCacheEntries(dir)
+			if err != nil {
+				return fmt.Errorf("read cache dir: %w", err)
+			}
+			cutoff := time.Now().Add(-maxAge)
+			removed := 0
+			var freed int64
+			for _, e := range entries {
+				if maxAge > 0 && !e.modTime.Before(cutoff) {
+					continue
+				}
+				if err := os.Remove(e.path); err != nil {
+					if errors.Is(err, os.ErrNotExist) {
+						continue
+					}
+					return fmt.Errorf("remove %s: %w", e.path, err)
+				}
+				removed++
+				freed += e.size
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "removed %d entries (%s) from %s\n", removed, humanBytes(freed), dir)
+			return nil
+		},
+	}
+	clearCmd.Flags().StringVar(&olderThan, "older-than", "", "Only delete entries last written longer ago than this (e.g., 12h, 7d)")
+
+	cacheCmd.AddCommand(statsCmd, clearCmd)
+	return cacheCmd
+}
+
+// parseAge parses a Go duration, also accepting whole days such as "7d".
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("bad day count %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative age %q", s)
+	}
+	return d, nil
+}
+
+// humanBytes formats n using binary units, e.g. "1.5 MiB".
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
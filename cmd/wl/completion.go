@@ -0,0 +1,85 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/render"
+)
+
+// outputFormats are the values -o accepts, in the order --help lists them.
+var outputFormats = []string{"table", "compact", "line", "summary", "json", "jsonl", "syms"}
+
+func isOutputFormat(s string) bool {
+	for _, f := range outputFormats {
+		if f == s {
+			return true
+		}
+	}
+	return false
+}
+
+// registerCompletions wires shell completion for --cols (column keys),
+// --col-set (column sets, including the config's), -o and --theme. Comma-separated
+// values complete their last segment.
+func registerCompletions(cmd *cobra.Command, configPath, configDir *string) {
+	_ = cmd.RegisterFlagCompletionFunc("cols", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		loadCompletionConfig(*configPath, *configDir)
+		return completeList(columns.AllKeys(), toComplete)
+	})
+	_ = cmd.RegisterFlagCompletionFunc("col-set", func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		loadCompletionConfig(*configPath, *configDir)
+		names := make([]string, 0, len(columns.Sets)+1)
+		for k := range columns.Sets {
+			names = append(names, k)
+		}
+		names = append(names, "yaml")
+		sort.Strings(names)
+		return completeList(names, toComplete)
+	})
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(outputFormats, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("theme", cobra.FixedCompletions(render.ThemeNames(), cobra.ShellCompDirectiveNoFileComp))
+}
+
+// loadCompletionConfig registers the config's column sets and computed
+// columns so they complete too. Errors are ignored: completion must not fail
+// because of a broken config.
+func loadCompletionConfig(configPath, configDir string) {
+	vp, err := loadViper(resolveConfigPath(configPath, configDir, resolveHome()))
+	if err != nil {
+		return
+	}
+	var cfg struct {
+		ColumnSets map[string][]string `mapstructure:"col_sets"`
+		Computed   map[string]string   `mapstructure:"computed"`
+	}
+	if err := vp.Unmarshal(&cfg); err != nil {
+		return
+	}
+	for k, v := range cfg.ColumnSets {
+		if v != nil {
+			columns.Sets[k] = v
+		}
+	}
+	_ = registerConfigColumns(cfg.Computed, nil)
+}
+
+// completeList completes the segment after the last comma of toComplete
+// against values, keeping the earlier segments as the completion prefix.
+func completeList(values []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix, last := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, last = toComplete[:i+1], toComplete[i+1:]
+	}
+	last = strings.ToLower(last)
+	var out []string
+	for _, v := range values {
+		if strings.HasPrefix(strings.ToLower(v), last) {
+			out = append(out, prefix+v)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
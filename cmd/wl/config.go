@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/render"
+)
+
+// resolveHome returns the wl home directory: WL_HOME (or Wl_HOME), else ~/.wl.
+func resolveHome() string {
+	wlHome := os.Getenv("WL_HOME")
+	if wlHome == "" {
+		wlHome = os.Getenv("Wl_HOME")
+	}
+	if wlHome == "" {
+		userHome, _ := os.UserHomeDir()
+		wlHome = filepath.Join(userHome, ".wl")
+	}
+	return wlHome
+}
+
+// resolveConfigPath locates config.yaml, decoupled from WL home:
+// 1) --config file, 2) --config-dir, 3) WL_CONFIG_DIR, 4) wlHome/config.yaml
+func resolveConfigPath(flagPath, flagDir, wlHome string) string {
+	if strings.TrimSpace(flagPath) != "" {
+		return flagPath
+	}
+	cfgDir := strings.TrimSpace(flagDir)
+	if cfgDir == "" {
+		cfgDir = strings.TrimSpace(os.Getenv("WL_CONFIG_DIR"))
+	}
+	if cfgDir == "" {
+		cfgDir = wlHome
+	}
+	return filepath.Join(resolvePath(cfgDir, ""), "config.yaml")
+}
+
+// defaultWatchlistPath returns the watchlist loaded when no path is given:
+// def (default_watchlist, relative to wlHome), else wlHome/watchlist.
+func defaultWatchlistPath(def, wlHome string) string {
+	if strings.TrimSpace(def) == "" {
+		def = filepath.Join(wlHome, "watchlist")
+	}
+	return resolvePath(def, wlHome)
+}
+
+// registerConfigColumns registers the config's computed columns, then its
+// aliases (which may name computed columns), each in key order.
+func registerConfigColumns(computed, aliases map[string]string) error {
+	computedKeys := make([]string, 0, len(computed))
+	for k := range computed {
+		computedKeys = append(computedKeys, k)
+	}
+	sort.Strings(computedKeys)
+	for _, k := range computedKeys {
+		if err := columns.RegisterComputed(k, computed[k]); err != nil {
+			return configErrorf("config: %w", err)
+		}
+	}
+	aliasKeys := make([]string, 0, len(aliases))
+	for k := range aliases {
+		aliasKeys = append(aliasKeys, k)
+	}
+	sort.Strings(aliasKeys)
+	for _, k := range aliasKeys {
+		if err := columns.AddAlias(k, aliases[k]); err != nil {
+			return configErrorf("config: %w", err)
+		}
+	}
+	return nil
+}
+
+// colorRuleConfig is one color_rules entry: color a column's cells red,
+// green, ... when their value compares to value by op.
+type colorRuleConfig struct {
+	Op    string `yaml:"op" mapstructure:"op"`
+	Value string `yaml:"value" mapstructure:"value"`
+	Color string `yaml:"color" mapstructure:"color"`
+}
+
+// parseColorRules turns color_rules from config (or a view, named by where
+// in errors) into render rules, columns in key order and each column's rules
+// in the order written.
+func parseColorRules(cfg map[string][]colorRuleConfig, where string) ([]render.ColorRule, error) {
+	keys := make([]string, 0, len(cfg))
+	for k := range cfg {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var rules []render.ColorRule
+	for _, k := range keys {
+		for _, rc := range cfg[k] {
+			r, err := render.ParseColorRule(k, rc.Op, rc.Value, rc.Color)
+			if err != nil {
+				return nil, configErrorf("%s: %w", where, err)
+			}
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// loadViper reads the config at path; a missing file yields an empty config.
+func loadViper(path string) (*viper.Viper, error) {
+	vp := viper.New()
+	vp.SetConfigType("yaml")
+	vp.SetConfigFile(path)
+	if st, err := os.Stat(path); err == nil && !st.IsDir() {
+		if err := vp.ReadInConfig(); err != nil {
+			return nil, configErrorf("load config: %w", err)
+		}
+	}
+	return vp, nil
+}
+
+// resolveCacheDir returns the persistent cache directory: --cache-dir when
+// given, else cache.dir from config, resolved against wlHome. Empty means
+// no file cache is configured.
+func resolveCacheDir(cmd *cobra.Command, cfgDir, flagDir, wlHome string) string {
+	if cmd.Flags().Changed("cache-dir") {
+		return resolvePath(flagDir, wlHome)
+	}
+	if dir := strings.TrimSpace(cfgDir); dir != "" {
+		return resolvePath(dir, wlHome)
+	}
+	return ""
+}
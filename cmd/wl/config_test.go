@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigOutputDefaults(t *testing.T) {
+	home := t.TempDir()
+	writeFile(t, home, "config.yaml", "output: json\npretty: true\n")
+	writeFile(t, home, "us.yaml", "watchlist:\n  - sym: AAPL\n")
+	cacheQuote(t, home+"/cache", "AAPL", "price", priceModule(180, 1.25))
+	args := []string{"--offline", "--cache-dir", "cache", "-c", "sym,price", "us.yaml"}
+
+	r := runWL(t, home, nil, args...)
+	if r.code != exitOK {
+		t.Fatalf("exit %d: %s", r.code, r.stderr)
+	}
+	var lists []map[string]any
+	if err := json.Unmarshal([]byte(r.stdout), &lists); err != nil {
+		t.Fatalf("config output: json not used: %v\n%s", err, r.stdout)
+	}
+	if !strings.Contains(r.stdout, "\n  ") {
+		t.Errorf("config pretty: true not used:\n%s", r.stdout)
+	}
+	if !strings.Contains(r.stdout, `"180.00"`) {
+		t.Errorf("price missing from output:\n%s", r.stdout)
+	}
+
+	// flags override config
+	r = runWL(t, home, nil, append(args, "-o", "syms")...)
+	if r.code != exitOK || strings.TrimSpace(r.stdout) != "AAPL" {
+		t.Errorf("-o syms: exit %d, stdout %q", r.code, r.stdout)
+	}
+
+	writeFile(t, home, "config.yaml", "output: xml\n")
+	if r = runWL(t, home, nil, append(args, "-o", "table")...); r.code != exitConfig {
+		t.Errorf("bad config output: exit %d, want %d (%s)", r.code, exitConfig, r.stderr)
+	}
+}
+
+func TestResolveConfigPath(t *testing.T) {
+	home, dir, env := t.TempDir(), t.TempDir(), t.TempDir()
+	for _, tc := range []struct {
+		name, flagPath, flagDir, envDir, want string
+	}{
+		{"WL_HOME only", "", "", "", filepath.Join(home, "config.yaml")},
+		{"WL_CONFIG_DIR", "", "", env, filepath.Join(env, "config.yaml")},
+		{"--config-dir beats WL_CONFIG_DIR", "", dir, env, filepath.Join(dir, "config.yaml")},
+		{"--config beats both", "/etc/wl.yaml", dir, env, "/etc/wl.yaml"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("WL_CONFIG_DIR", tc.envDir)
+			if got := resolveConfigPath(tc.flagPath, tc.flagDir, home); got != tc.want {
+				t.Errorf("resolveConfigPath = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConfigDirSeparateFromHome(t *testing.T) {
+	home, cfgDir := t.TempDir(), t.TempDir()
+	// the default watchlist stays under WL_HOME; config.yaml comes from the config dir
+	writeFile(t, home, "watchlist/us.yaml", "watchlist:\n  - sym: AAPL\n")
+	writeFile(t, home, "config.yaml", "output: json\n")
+	writeFile(t, cfgDir, "config.yaml", "output: syms\n")
+
+	for _, tc := range []struct {
+		name string
+		env  []string
+		args []string
+		want string
+	}{
+		{"WL_HOME only", nil, nil, "["},
+		{"WL_CONFIG_DIR", []string{"WL_CONFIG_DIR=" + cfgDir}, nil, "AAPL"},
+		{"--config-dir", nil, []string{"--config-dir", cfgDir}, "AAPL"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := runWL(t, home, tc.env, append([]string{"--offline", "--cache-dir", "cache", "-c", "sym"}, tc.args...)...)
+			if r.code != exitOK || !strings.HasPrefix(strings.TrimSpace(r.stdout), tc.want) {
+				t.Errorf("exit %d, stdout %q, want prefix %q (%s)", r.code, r.stdout, tc.want, r.stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/komsit37/wl/pkg/wl/source"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// newDiffCmd builds `wl diff OLD NEW`, which loads two watchlist YAML
+// sources (files, directories or globs), matches lists by name and prints
+// the symbols added, removed and retained in each. Nothing is fetched.
+func newDiffCmd() *cobra.Command {
+	var noColor bool
+	cmd := &cobra.Command{
+		Use:   "diff OLD NEW",
+		Short: "Show symbols added, removed and retained between two watchlist sources",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return &usageError{err: err}
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			before, err := loadForDiff(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			after, err := loadForDiff(cmd, args[1])
+			if err != nil {
+				return err
+			}
+			color := !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+			writeListDiffs(cmd.OutOrStdout(), diffLists(before, after), color)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "disable color output")
+	return cmd
+}
+
+// unnamedList labels lists that were named only after their file, so that
+// `wl diff jan.yaml feb.yaml` compares the files' unnamed lists.
+const unnamedList = "(unnamed)"
+
+// loadForDiff loads spec, renaming lists the loader named after a single
+// file to unnamedList.
+func loadForDiff(cmd *cobra.Command, spec string) ([]types.Watchlist, error) {
+	lists, err := source.Load(cmd.Context(), source.YAMLSource{KeepEmpty: true}, spec)
+	if err != nil {
+		return nil, err
+	}
+	if st, err := os.Stat(spec); err == nil && !st.IsDir() {
+		base := strings.TrimSuffix(filepath.Base(spec), filepath.Ext(spec))
+		for i := range lists {
+			if lists[i].Name == base {
+				lists[i].Name = unnamedList
+			}
+		}
+	}
+	return lists, nil
+}
+
+// listDiff is how one list's symbols changed; a list on only one side has
+// all its symbols added or removed.
+type listDiff struct {
+	Name                     string
+	Added, Removed, Retained []string
+	OnlyBefore, OnlyAfter    bool
+}
+
+// diffLists matches lists by name, in the order they first appear (old
+// side first). Lists sharing a name on one side are combined, and symbols
+// compare case-insensitively, keeping their first spelling.
+func diffLists(before, after []types.Watchlist) []listDiff {
+	oldSyms, oldOrder := symsByList(before)
+	newSyms, newOrder := symsByList(after)
+	var out []listDiff
+	for _, name := range oldOrder {
+		d := listDiff{Name: name}
+		inNew, ok := newSyms[name]
+		if !ok {
+			d.OnlyBefore = true
+		}
+		for _, s := range oldSyms[name] {
+			if containsSym(inNew, s) {
+				d.Retained = append(d.Retained, s)
+			} else {
+				d.Removed = append(d.Removed, s)
+			}
+		}
+		for _, s := range inNew {
+			if !containsSym(oldSyms[name], s) {
+				d.Added = append(d.Added, s)
+			}
+		}
+		out = append(out, d)
+	}
+	for _, name := range newOrder {
+		if _, ok := oldSyms[name]; !ok {
+			out = append(out, listDiff{Name: name, Added: newSyms[name], OnlyAfter: true})
+		}
+	}
+	return out
+}
+
+// symsByList returns each list's distinct symbols and the list names in
+// order of first appearance.
+func symsByList(lists []types.Watchlist) (map[string][]string, []string) {
+	syms := map[string][]string{}
+	var order []string
+	for _, l := range lists {
+		if _, ok := syms[l.Name]; !ok {
+			order = append(order, l.Name)
+			syms[l.Name] = nil
+		}
+		for _, it := range l.Items {
+			s := strings.TrimSpace(it.Sym)
+			if s != "" && !containsSym(syms[l.Name], s) {
+				syms[l.Name] = append(syms[l.Name], s)
+			}
+		}
+	}
+	return syms, order
+}
+
+func containsSym(syms []string, s string) bool {
+	for _, x := range syms {
+		if strings.EqualFold(x, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeListDiffs prints one block per list: a header with counts, then a
+// "+" line per added and a "-" line per removed symbol (green and red when
+// color is on) and one "=" line listing the retained ones.
+func writeListDiffs(w io.Writer, diffs []listDiff, color bool) {
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return "\x1b[" + code + "m" + s + "\x1b[0m"
+	}
+	for i, d := range diffs {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		switch {
+		case d.OnlyAfter:
+			fmt.Fprintf(w, "%s: %s (%d)\n", d.Name, paint("32", "added list"), len(d.Added))
+		case d.OnlyBefore:
+			fmt.Fprintf(w, "%s: %s (%d)\n", d.Name, paint("31", "removed list"), len(d.Removed))
+		default:
+			fmt.Fprintf(w, "%s: +%d -%d =%d\n", d.Name, len(d.Added), len(d.Removed), len(d.Retained))
+		}
+		for _, s := range d.Added {
+			fmt.Fprintln(w, paint("32", "  + "+s))
+		}
+		for _, s := range d.Removed {
+			fmt.Fprintln(w, paint("31", "  - "+s))
+		}
+		if len(d.Retained) > 0 {
+			fmt.Fprintf(w, "  = %s\n", strings.Join(d.Retained, " "))
+		}
+	}
+}
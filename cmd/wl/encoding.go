@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+)
+
+// outputEncodings maps --encoding names to charsets; nil is UTF-8, written
+// as-is.
+var outputEncodings = map[string]encoding.Encoding{
+	"utf-8":     nil,
+	"utf8":      nil,
+	"shift-jis": japanese.ShiftJIS,
+	"shift_jis": japanese.ShiftJIS,
+	"sjis":      japanese.ShiftJIS,
+	"euc-jp":    japanese.EUCJP,
+	"eucjp":     japanese.EUCJP,
+}
+
+// lookupEncoding returns the charset for an --encoding name; nil means UTF-8.
+func lookupEncoding(name string) (encoding.Encoding, error) {
+	enc, ok := outputEncodings[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		names := make([]string, 0, len(outputEncodings))
+		for n := range outputEncodings {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown encoding %q (supported: %s)", name, strings.Join(names, ", "))
+	}
+	return enc, nil
+}
+
+// encodeWriter wraps w so UTF-8 written to it reaches w transcoded to enc,
+// with characters the charset lacks written as '?'. The returned flush must
+// be called once output is complete.
+func encodeWriter(w io.Writer, enc encoding.Encoding) (io.Writer, func() error) {
+	if enc == nil {
+		return w, func() error { return nil }
+	}
+	check := enc.NewEncoder()
+	replace := runes.Map(func(r rune) rune {
+		if r < 0x80 {
+			return r
+		}
+		if _, err := check.String(string(r)); err != nil {
+			return '?'
+		}
+		return r
+	})
+	tw := transform.NewWriter(w, transform.Chain(replace, enc.NewEncoder()))
+	return tw, tw.Close
+}
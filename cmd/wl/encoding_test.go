@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+func TestEncodeWriterRoundTrip(t *testing.T) {
+	const in = "7203.T  トヨタ自動車  2,850.00\n"
+	for _, name := range []string{"shift-jis", "SJIS", "euc-jp"} {
+		enc, err := lookupEncoding(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		w, flush := encodeWriter(&buf, enc)
+		if _, err := io.WriteString(w, in); err != nil {
+			t.Fatal(err)
+		}
+		if err := flush(); err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(buf.Bytes(), []byte(in)) {
+			t.Errorf("%s: output left as UTF-8", name)
+		}
+		out, _, err := transform.Bytes(enc.NewDecoder(), buf.Bytes())
+		if err != nil || string(out) != in {
+			t.Errorf("%s: decoded %q, %v; want %q", name, out, err, in)
+		}
+	}
+	if enc, _ := lookupEncoding("shift_jis"); enc != japanese.ShiftJIS {
+		t.Errorf("shift_jis = %v, want Shift-JIS", enc)
+	}
+}
+
+func TestEncodeWriterUnmappable(t *testing.T) {
+	enc, _ := lookupEncoding("shift-jis")
+	var buf bytes.Buffer
+	w, flush := encodeWriter(&buf, enc)
+	if _, err := io.WriteString(w, "ソニー 😀 ▁▂█ é\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := flush(); err != nil {
+		t.Fatal(err)
+	}
+	out, _, _ := transform.Bytes(enc.NewDecoder(), buf.Bytes())
+	if string(out) != "ソニー ? ??? ?\n" {
+		t.Errorf("decoded %q", out)
+	}
+}
+
+func TestLookupEncoding(t *testing.T) {
+	for _, name := range []string{"UTF-8", " utf8 "} {
+		if enc, err := lookupEncoding(name); err != nil || enc != nil {
+			t.Errorf("lookupEncoding(%q) = %v, %v; want UTF-8 (nil)", name, enc, err)
+		}
+	}
+	if _, err := lookupEncoding("latin-1"); err == nil {
+		t.Error("latin-1: want an error")
+	}
+	var buf bytes.Buffer
+	if w, flush := encodeWriter(&buf, nil); w != &buf || flush() != nil {
+		t.Error("UTF-8 should write through unchanged")
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/komsit37/wl/pkg/wl/render"
+	"github.com/komsit37/wl/pkg/wl/source"
+)
+
+// Exit codes, so scripts can tell failures apart. Keep exitCodesHelp in sync.
+const (
+	exitOK     = 0
+	exitFailed = 1 // anything unclassified, e.g. --alert-exit
+	exitUsage  = 2 // bad flag, flag value or argument, or a symbol limit exceeded
+	exitConfig = 3 // config file unreadable or invalid
+	exitLoad   = 4 // watchlist not found or unreadable
+	exitRender = 5 // fetching or rendering failed, e.g. --timeout
+)
+
+const exitCodesHelp = `Exit codes:
+  0  success
+  1  other failure (e.g. --alert-exit)
+  2  usage error: bad flag, flag value or argument, or too many symbols
+     for --max-symbols or --transpose
+  3  config error: config file unreadable or invalid
+  4  load error: watchlist not found or unreadable
+  5  fetch/render error, e.g. --timeout reached`
+
+// usageError and configError mark errors for exitCode; their messages are
+// the wrapped error's.
+type usageError struct{ err error }
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+type configError struct{ err error }
+
+func (e *configError) Error() string { return e.err.Error() }
+func (e *configError) Unwrap() error { return e.err }
+
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+func configErrorf(format string, args ...any) error {
+	return &configError{err: fmt.Errorf(format, args...)}
+}
+
+// exitCode maps an error returned by the root command to an exit code.
+func exitCode(err error) int {
+	var (
+		ue *usageError
+		lm *render.LimitError
+		ce *configError
+		le *source.LoadError
+		re *render.RenderError
+	)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &ue), errors.As(err, &lm):
+		return exitUsage
+	case errors.As(err, &ce):
+		return exitConfig
+	case errors.As(err, &le):
+		return exitLoad
+	case errors.As(err, &re):
+		return exitRender
+	}
+	return exitFailed
+}
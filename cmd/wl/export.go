@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/komsit37/wl/pkg/wl/source"
+)
+
+// newExportCmd builds `wl export [path]`, which loads watchlist YAML (a
+// file, directory or glob; default: the default watchlist) and writes every
+// list to stdout as a single YAML document, nested by list name.
+func newExportCmd(configPath, configDir *string) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "export [path]",
+		Short: "Flatten watchlist YAML (e.g. a directory) into one YAML document",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			if f := strings.ToLower(strings.TrimSpace(format)); f != "yaml" && f != "yml" {
+				return usageErrorf("unsupported export format %q (supported: yaml)", format)
+			}
+			var spec string
+			if len(args) == 1 {
+				spec = args[0]
+			} else {
+				wlHome := resolveHome()
+				vp, err := loadViper(resolveConfigPath(*configPath, *configDir, wlHome))
+				if err != nil {
+					return err
+				}
+				spec = defaultWatchlistPath(vp.GetString("default_watchlist"), wlHome)
+			}
+			lists, err := source.Load(cmd.Context(), source.YAMLSource{}, spec)
+			if err != nil {
+				return err
+			}
+			return source.EncodeYAML(cmd.OutOrStdout(), lists)
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "yaml", "output format (only yaml)")
+	return cmd
+}
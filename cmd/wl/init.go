@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// initConfig is the commented config.yaml written by `wl init`.
+const initConfig = `# wl config. CLI flags always override these settings.
+
+# Persistent Yahoo Finance cache; a relative dir resolves against WL home.
+cache:
+  dir: cache
+  ttl: 2m
+
+# Named column sets, used with --col-set (built-in sets exist for each Yahoo
+# module: price, summaryDetail, financialData, ...; "yaml" expands to the
+# custom fields of your watchlist items).
+col_sets:
+  sym: [sym, name]
+  overview: [price, chg%, sector, mktcap, pe]
+  valuation: [pe, pe_fwd, ps_ttm, roe%, roa%]
+  dividends: [div_rate, div_yield%, payout%, ex_div]
+
+# Column sets shown when neither --col-set nor --cols is given.
+col_set: [sym, overview]
+
+# Extra columns appended after the sets; "-col" removes one.
+# columns: [note, -sector]
+
+# Output defaults: format (table|compact|line|summary|json|jsonl|syms),
+# pretty JSON and table color theme (dark|bright|blue|cyan|green|magenta|
+# red|yellow|plain).
+# output: table
+# pretty: false
+# theme: dark
+
+# Watchlist loaded when no path is given (default: watchlist/ under WL home).
+# default_watchlist: watchlist
+`
+
+// initWatchlist is the starter watchlist/example.yaml written by `wl init`.
+const initWatchlist = `# A starter watchlist. Items need a sym; other keys (note, tags, ...) are
+# custom fields you can show as columns. Groups nest with name + watchlist.
+watchlist:
+  - name: us
+    watchlist:
+      - sym: AAPL
+        note: iPhone maker
+      - sym: MSFT
+  - name: japan
+    watchlist:
+      - sym: 7203.T
+        note: Toyota
+      - sym: 6758.T
+`
+
+// newInitCmd builds `wl init`, which scaffolds WL home: config.yaml (at the
+// path the root command would read) and watchlist/example.yaml. Existing
+// files are kept unless --force is given.
+func newInitCmd(configPath, configDir *string) *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create WL home with a commented config.yaml and a sample watchlist",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			wlHome := resolveHome()
+			files := []struct{ path, content string }{
+				{resolveConfigPath(*configPath, *configDir, wlHome), initConfig},
+				{filepath.Join(wlHome, "watchlist", "example.yaml"), initWatchlist},
+			}
+			if err := os.MkdirAll(wlHome, 0o755); err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			for _, f := range files {
+				if _, err := os.Stat(f.path); err == nil && !force {
+					fmt.Fprintf(out, "exists  %s (use --force to overwrite)\n", f.path)
+					continue
+				}
+				if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+					return err
+				}
+				if err := os.WriteFile(f.path, []byte(f.content), 0o644); err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "created %s\n", f.path)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite existing files")
+	return cmd
+}
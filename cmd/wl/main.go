@@ -1,16 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/jedib0t/go-pretty/v6/list"
 
@@ -46,17 +49,86 @@ func resolvePath(p string, baseDir string) string {
 	return filepath.Join(baseDir, p)
 }
 
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// readColsFile reads column names for --cols-file: one per line and/or
+// comma-separated, skipping blanks and lines starting with '#'.
+func readColsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, usageErrorf("--cols-file: %w", err)
+	}
+	var out []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, splitList(line)...)
+	}
+	return out, nil
+}
+
+// isCurrencyCode reports whether s looks like an ISO currency code, e.g. USD.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// excludeColumns returns cols without those naming any of names, compared
+// by canonical key so aliases match; matched records each name that removed
+// something.
+func excludeColumns(cols, names []string, matched map[string]bool) []string {
+	if len(names) == 0 {
+		return cols
+	}
+	out := make([]string, 0, len(cols))
+	for _, c := range cols {
+		ck, _ := columns.Canonical(c)
+		drop := false
+		for _, n := range names {
+			if nk, _ := columns.Canonical(n); nk == ck {
+				matched[n] = true
+				drop = true
+			}
+		}
+		if !drop {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
 func main() {
 	var (
 		flagSource       string
 		flagDBDSN        string
 		flagOutput       string
 		flagNoColor      bool
+		flagColor        bool
 		flagPretty       bool
 		flagCols         string
 		flagColSet       string
 		flagConfigPath   string
+		flagConfigDir    string
 		flagFilter       string
+		flagFilterAnd    bool
 		flagList         bool
 		flagListColumns  bool
 		flagListColSets  bool
@@ -64,8 +136,69 @@ func main() {
 		flagSortBy       string
 		flagSortDesc     bool
 		flagCacheDisable bool
+		flagOffline      bool
 		flagCacheTTL     time.Duration
 		flagCacheDir     string
+		flagGitRepo      string
+		flagGitPath      string
+		flagGitTTL       time.Duration
+		flagExplain      string
+		flagNoHeader     bool
+		flagASCII        bool
+		flagSymsSep      string
+		flagSymsStrip    string
+		flagSymsPerList  bool
+		flagHeatmap      string
+		flagHeatmapLow   string
+		flagHeatmapHigh  string
+		flagGroupBy      string
+		flagSparkDays    int
+		flagWarm         bool
+		flagConcurrency  int
+		flagFetchRetries int
+		flagBatchSize    int
+		flagLimit        int
+		flagCPUProfile   string
+		flagTimeout      time.Duration
+		flagReportErrors bool
+		flagMemProfile   string
+		flagMaxSymbols   int
+		flagOutFile      string
+		flagEncoding     string
+		flagQuick        bool
+		flagTag          string
+		flagAlignDec     bool
+		flagWatch        time.Duration
+		flagWatchChange  bool
+		flagPager        bool
+		flagAutoFit      bool
+		flagTranspose    bool
+		flagTheme        string
+		flagAnnotate     string
+		flagAnnotateOver bool
+		flagNoPager      bool
+		flagMarketHours  bool
+		flagAlerts       []string
+		flagNotify       bool
+		flagJSONMeta     bool
+		flagBySym        bool
+		flagJSONRaw      bool
+		flagSelect       string
+		flagKeepEmpty    bool
+		flagMergeLists   bool
+		flagDedupe       bool
+		flagView         string
+		flagSortMissing  string
+		flagStable       bool
+		flagAlertExit    bool
+		flagPercentDec   int
+		flagColsFile     string
+		flagExclude      string
+		flagAsOfFormat   string
+		flagDateFormat   string
+		flagConvertTo    string
+		flagMoverThresh  float64
+		flagOnlyMovers   bool
 	)
 
 	// AppConfig represents configuration loaded from Viper.
@@ -73,18 +206,65 @@ func main() {
 		Columns    []string            `mapstructure:"columns"`
 		ColSet     []string            `mapstructure:"col_set"`
 		ColumnSets map[string][]string `mapstructure:"col_sets"`
+		// Computed maps column names to arithmetic over other columns,
+		// e.g. upside%: "(tgt_mean - price)/price*100".
+		Computed map[string]string `mapstructure:"computed"`
+		// Aliases adds column names, e.g. mcap: mktcap.
+		Aliases map[string]string `mapstructure:"aliases"`
+		// PercentDecimals sets --percent-decimals; nil leaves the flag default.
+		PercentDecimals *int `mapstructure:"percent_decimals"`
+		// ConvertTo sets --convert-to; FXRates are static rates by currency
+		// pair (JPYUSD: 0.0067 is one JPY in USD), used before Yahoo's.
+		ConvertTo string             `mapstructure:"convert_to"`
+		FXRates   map[string]float64 `mapstructure:"fx_rates"`
+		// ColorRules color table cells by threshold, e.g.
+		// pe_ttm: [{op: ">", value: 30, color: red}].
+		ColorRules map[string][]colorRuleConfig `mapstructure:"color_rules"`
+		// Views are named presets selected with --view NAME.
+		Views map[string]view `mapstructure:"views"`
 		// DefaultWatchlist sets the default watchlist path when no CLI path arg is provided.
 		// Can be absolute or relative (relative resolves against wlHome).
 		DefaultWatchlist string `mapstructure:"default_watchlist"`
-		Cache            struct {
+		// DefaultFilter is the list-name filter used when --filter is not
+		// given (same syntax); --filter-and ANDs --filter with it.
+		DefaultFilter string `mapstructure:"default_filter"`
+		// Output defaults; the matching CLI flags override them.
+		Output      string `mapstructure:"output"`
+		Pretty      bool   `mapstructure:"pretty"`
+		Theme       string `mapstructure:"theme"`
+		NoColor     bool   `mapstructure:"no_color"`
+		NoHeader    bool   `mapstructure:"no_header"`
+		MaxColWidth int    `mapstructure:"max_col_width"`
+		Sort        string `mapstructure:"sort"`
+		Desc        bool   `mapstructure:"desc"`
+		MaxSymbols  int    `mapstructure:"max_symbols"`
+		Cache       struct {
 			Disabled bool   `mapstructure:"disabled"`
 			Dir      string `mapstructure:"dir"`
 			TTL      string `mapstructure:"ttl"`
 		} `mapstructure:"cache"`
+		// Heatmap overrides the gradient endpoints used by --heatmap.
+		Heatmap struct {
+			Low  string `mapstructure:"low"`
+			High string `mapstructure:"high"`
+		} `mapstructure:"heatmap"`
+		// MarketHours sets a fixed weekday session for --market-hours-only;
+		// without it the session is probed from price.marketState.
+		MarketHours struct {
+			TZ    string `mapstructure:"tz"`
+			Open  string `mapstructure:"open"`
+			Close string `mapstructure:"close"`
+		} `mapstructure:"market_hours"`
+		// Git configures --source git; the repository is cloned under wlHome/cache/git.
+		Git struct {
+			Repo string `mapstructure:"repo"`
+			Path string `mapstructure:"path"`
+			TTL  string `mapstructure:"ttl"`
+		} `mapstructure:"git"`
 	}
 
 	rootCmd := &cobra.Command{
-		Use:   "wl [file|dir]",
+		Use:   "wl [file|dir|url|-]",
 		Short: "Render a watchlist",
 		Args: func(cmd *cobra.Command, args []string) error {
 			// Allow running with no args when listing columns
@@ -95,46 +275,34 @@ func main() {
 			}
 			// Allow 0 or 1 arg; 0 means default watchlist dir under WL_HOME or ~/.wl
 			if len(args) > 1 {
-				return errors.New("accepts at most 1 path argument (YAML file or directory)")
+				return usageErrorf("accepts at most 1 path argument (YAML file, directory, URL or - for stdin)")
 			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
-			// Resolve home directory for wl:
-			// 1) --config points to a file; its directory becomes WL home if not default
-			// 2) WL_HOME or Wl_HOME env var points to base directory
-			// 3) default: ~/.wl
-			wlHome := os.Getenv("WL_HOME")
-			if wlHome == "" {
-				wlHome = os.Getenv("Wl_HOME")
-			}
-			if wlHome == "" {
-				userHome, _ := os.UserHomeDir()
-				wlHome = filepath.Join(userHome, ".wl")
-			}
-
-			// Configure Viper
-			vp := viper.New()
-			vp.SetConfigType("yaml")
-			// If --config specified, use it; otherwise use wlHome/config.yaml
-			cfgPath := flagConfigPath
-			if strings.TrimSpace(cfgPath) == "" {
-				cfgPath = filepath.Join(wlHome, "config.yaml")
-			}
-			vp.SetConfigFile(cfgPath)
-			// Read config only if the file exists; otherwise silently ignore
-			if st, err := os.Stat(cfgPath); err == nil && !st.IsDir() {
-				if err := vp.ReadInConfig(); err != nil {
-					return fmt.Errorf("load config: %w", err)
+			if flagCPUProfile != "" || flagMemProfile != "" {
+				stop, err := startProfiling(flagCPUProfile, flagMemProfile)
+				if err != nil {
+					return err
 				}
+				defer func() {
+					if err := stop(); err != nil {
+						fmt.Fprintln(os.Stderr, "Error:", err)
+					}
+				}()
+			}
+			wlHome := resolveHome()
+			vp, err := loadViper(resolveConfigPath(flagConfigPath, flagConfigDir, wlHome))
+			if err != nil {
+				return err
 			}
 			// Back-compat alias: allow "col-sets" and "col_set" keys
 			// to be recognized alongside "col_sets" / "col_set".
 			// We’ll map "col-sets" to ColumnSets if present.
 			var cfg AppConfig
 			if err := vp.Unmarshal(&cfg); err != nil {
-				return fmt.Errorf("parse config: %w", err)
+				return configErrorf("parse config: %w", err)
 			}
 			if cfg.ColumnSets == nil {
 				var m map[string][]string
@@ -155,6 +323,70 @@ func main() {
 					cfg.DefaultWatchlist = s
 				}
 			}
+			// Output defaults from config apply only when the flag was not given;
+			// bad values are config errors even when a flag overrides them
+			if o := strings.TrimSpace(cfg.Output); o != "" && !isOutputFormat(o) {
+				return configErrorf("config output: unknown format %q (want one of %s)", o, strings.Join(outputFormats, ", "))
+			}
+			if t := cfg.Theme; strings.TrimSpace(t) != "" && !render.IsTheme(t) {
+				return configErrorf("config theme: unknown theme %q (want one of %s)", t, strings.Join(render.ThemeNames(), ", "))
+			}
+			if !cmd.Flags().Changed("output") && strings.TrimSpace(cfg.Output) != "" {
+				flagOutput = strings.TrimSpace(cfg.Output)
+			}
+			if !cmd.Flags().Changed("theme") && strings.TrimSpace(cfg.Theme) != "" {
+				flagTheme = cfg.Theme
+			}
+			if !render.IsTheme(flagTheme) {
+				return usageErrorf("--theme: unknown theme %q (want one of %s)", flagTheme, strings.Join(render.ThemeNames(), ", "))
+			}
+			if !cmd.Flags().Changed("pretty") && cfg.Pretty {
+				flagPretty = true
+			}
+			if !cmd.Flags().Changed("no-color") && cfg.NoColor {
+				flagNoColor = true
+			}
+			// Color is off for pipes, files and NO_COLOR unless --color
+			// forces it (https://no-color.org)
+			if flagColor {
+				if cmd.Flags().Changed("no-color") {
+					return usageErrorf("--color and --no-color are mutually exclusive")
+				}
+				flagNoColor = false
+			} else if os.Getenv("NO_COLOR") != "" || strings.TrimSpace(flagOutFile) != "" || !isTerminal(os.Stdout) {
+				flagNoColor = true
+			}
+			if !cmd.Flags().Changed("no-header") && cfg.NoHeader {
+				flagNoHeader = true
+			}
+			if !cmd.Flags().Changed("max-col-width") && cfg.MaxColWidth > 0 {
+				flagMaxColWidth = cfg.MaxColWidth
+			}
+			if !cmd.Flags().Changed("sort") && strings.TrimSpace(cfg.Sort) != "" {
+				flagSortBy = strings.TrimSpace(cfg.Sort)
+			}
+			if !cmd.Flags().Changed("desc") && cfg.Desc {
+				flagSortDesc = true
+			}
+			if !cmd.Flags().Changed("max-symbols") && cfg.MaxSymbols > 0 {
+				flagMaxSymbols = cfg.MaxSymbols
+			}
+			if !cmd.Flags().Changed("percent-decimals") && cfg.PercentDecimals != nil {
+				flagPercentDec = *cfg.PercentDecimals
+			}
+			if flagPercentDec < 0 {
+				return usageErrorf("--percent-decimals must be >= 0")
+			}
+			columns.PercentDecimals = flagPercentDec
+			if !cmd.Flags().Changed("convert-to") && strings.TrimSpace(cfg.ConvertTo) != "" {
+				flagConvertTo = cfg.ConvertTo
+			}
+			flagConvertTo = strings.ToUpper(strings.TrimSpace(flagConvertTo))
+			if flagConvertTo != "" && !isCurrencyCode(flagConvertTo) {
+				return usageErrorf("--convert-to: %q is not a 3-letter currency code", flagConvertTo)
+			}
+			columns.AsOfFormat = flagAsOfFormat
+			columns.DateFormat = flagDateFormat
 			// Merge custom column sets from config into built-ins (override on collision)
 			if len(cfg.ColumnSets) > 0 {
 				for k, v := range cfg.ColumnSets {
@@ -164,11 +396,20 @@ func main() {
 					columns.Sets[k] = append([]string(nil), v...)
 				}
 			}
+			// Computed columns and aliases from config, registered before
+			// anything resolves or lists columns
+			if err := registerConfigColumns(cfg.Computed, cfg.Aliases); err != nil {
+				return err
+			}
+			colorRules, err := parseColorRules(cfg.ColorRules, "config color_rules")
+			if err != nil {
+				return err
+			}
 			// List available columns grouped by YF module (from registry)
 			if flagListColumns {
 				groups := columns.AvailableByModule()
 				// Stable module order preference
-				order := []string{"price", "assetProfile", "financialData", "summaryDetail", "base"}
+				order := []string{"price", "assetProfile", "financialData", "summaryDetail", "defaultKeyStatistics", "calendarEvents", "chart", "base"}
 				// Accent group name unless --no-color
 				grpStart, grpEnd := "", ""
 				if !flagNoColor {
@@ -212,34 +453,36 @@ func main() {
 			if ttlStr := strings.TrimSpace(cfg.Cache.TTL); ttlStr != "" {
 				dur, err := time.ParseDuration(ttlStr)
 				if err != nil {
-					return fmt.Errorf("invalid cache.ttl in config: %w", err)
+					return configErrorf("invalid cache.ttl in config: %w", err)
 				}
 				if dur <= 0 {
-					return fmt.Errorf("cache.ttl must be > 0 (got %s)", ttlStr)
+					return configErrorf("cache.ttl must be > 0 (got %s)", ttlStr)
 				}
 				cacheTTL = dur
 				haveCacheTTL = true
 			}
 			if cmd.Flags().Changed("cache-ttl") {
 				if flagCacheTTL <= 0 {
-					return errors.New("--cache-ttl must be greater than 0")
+					return usageErrorf("--cache-ttl must be greater than 0")
 				}
 				cacheTTL = flagCacheTTL
 				haveCacheTTL = true
 			}
 
-			cacheDir := ""
-			if dir := strings.TrimSpace(cfg.Cache.Dir); dir != "" {
-				cacheDir = resolvePath(dir, wlHome)
-			}
-			if cmd.Flags().Changed("cache-dir") {
-				cacheDir = resolvePath(flagCacheDir, wlHome)
+			cacheDir := resolveCacheDir(cmd, cfg.Cache.Dir, flagCacheDir, wlHome)
+			if flagOffline {
+				if cacheDisabled {
+					return usageErrorf("--offline reads only the cache; it conflicts with --cache-disable")
+				}
+				if cacheDir == "" {
+					return usageErrorf("--offline needs a persistent cache; pass --cache-dir or set cache.dir in config")
+				}
 			}
 
 			// List column sets (built-in + config) in compact format and exit
 			if flagListColSets {
 				// Determine module sets vs custom sets (from config.yaml)
-				moduleNames := map[string]bool{"price": true, "assetProfile": true, "financialData": true, "summaryDetail": true}
+				moduleNames := map[string]bool{"price": true, "assetProfile": true, "financialData": true, "summaryDetail": true, "defaultKeyStatistics": true, "calendarEvents": true}
 				// Accent set name unless --no-color
 				setStart, setEnd := "", ""
 				if !flagNoColor {
@@ -269,7 +512,7 @@ func main() {
 				}
 
 				// 1) Module sets (price, assetProfile, financialData, summaryDetail) in stable order
-				order := []string{"price", "assetProfile", "financialData", "summaryDetail"}
+				order := []string{"price", "assetProfile", "financialData", "summaryDetail", "defaultKeyStatistics", "calendarEvents"}
 				printedModule := false
 				for _, name := range order {
 					if cols, ok := columns.Sets[name]; ok && len(cols) > 0 {
@@ -310,6 +553,7 @@ func main() {
 			// Source
 			var src source.Source
 			spec := any(nil)
+			srcDesc := "" // recorded by --json-meta; defaults to the spec
 			switch flagSource {
 			case "yaml", "":
 				src = source.YAMLSource{}
@@ -317,22 +561,79 @@ func main() {
 				if len(args) == 1 {
 					spec = args[0]
 				} else {
-					def := cfg.DefaultWatchlist
-					if strings.TrimSpace(def) == "" {
-						def = filepath.Join(wlHome, "watchlist")
+					spec = defaultWatchlistPath(cfg.DefaultWatchlist, wlHome)
+				}
+			case "csv":
+				if len(args) != 1 {
+					return usageErrorf("--source csv requires a CSV file path argument")
+				}
+				src = source.CSVSource{}
+				spec = args[0]
+			case "json":
+				if len(args) != 1 {
+					return usageErrorf("--source json requires a JSON file path argument")
+				}
+				src = source.JSONSource{}
+				spec = args[0]
+			case "git":
+				repo := strings.TrimSpace(cfg.Git.Repo)
+				if cmd.Flags().Changed("repo") {
+					repo = strings.TrimSpace(flagGitRepo)
+				}
+				if repo == "" {
+					return usageErrorf("--source git requires --repo or git.repo in config")
+				}
+				gitTTL := source.DefaultGitTTL
+				if ttlStr := strings.TrimSpace(cfg.Git.TTL); ttlStr != "" {
+					dur, err := time.ParseDuration(ttlStr)
+					if err != nil {
+						return configErrorf("invalid git.ttl in config: %w", err)
 					}
-					spec = resolvePath(def, wlHome)
+					gitTTL = dur
+				}
+				if cmd.Flags().Changed("git-ttl") {
+					gitTTL = flagGitTTL
+				}
+				src = source.GitSource{
+					Repo:     repo,
+					CacheDir: filepath.Join(wlHome, "cache", "git"),
+					TTL:      gitTTL,
+				}
+				// Path inside the repo: --path, then positional arg, then config
+				switch {
+				case cmd.Flags().Changed("path"):
+					spec = flagGitPath
+				case len(args) == 1:
+					spec = args[0]
+				default:
+					spec = cfg.Git.Path
 				}
+				srcDesc = repo + "#" + fmt.Sprint(spec)
 			case "db":
-				return fmt.Errorf("db source not implemented: dsn=%s", flagDBDSN)
+				dsn := strings.TrimSpace(flagDBDSN)
+				if dsn == "" && len(args) == 1 {
+					dsn = args[0]
+				}
+				if dsn == "" {
+					return usageErrorf("--source db requires --db-dsn (e.g. a SQLite file path)")
+				}
+				src = source.DBSource{DSN: dsn}
+				spec = dsn
 			default:
-				return fmt.Errorf("unknown source: %s", flagSource)
+				return usageErrorf("unknown source: %s", flagSource)
 			}
 
-			// Renderer
-			var rnd render.Renderer
-			switch flagOutput {
-			case "table", "":
+			// Yahoo client honoring cache settings; shared so every consumer
+			// (renderer, alerts, probes) reuses one in-memory cache
+			var sharedClient *yfgo.Client
+			newClient := func() (*yfgo.Client, error) {
+				if sharedClient != nil {
+					return sharedClient, nil
+				}
+				if flagOffline {
+					sharedClient = render.NewOfflineClient(cacheDir)
+					return sharedClient, nil
+				}
 				opts := make([]yfgo.ClientOption, 0, 3)
 				if cacheDisabled {
 					opts = append(opts, yfgo.WithCacheDisabled())
@@ -340,7 +641,7 @@ func main() {
 					if cacheDir != "" {
 						store, err := yfgo.NewFileCacheStore(cacheDir)
 						if err != nil {
-							return fmt.Errorf("init cache store (%s): %w", cacheDir, err)
+							return nil, fmt.Errorf("init cache store (%s): %w", cacheDir, err)
 						}
 						opts = append(opts, yfgo.WithCacheStore(store))
 					}
@@ -348,25 +649,82 @@ func main() {
 						opts = append(opts, yfgo.WithDefaultCacheTTL(cacheTTL))
 					}
 				}
-				client := yfgo.NewClient(opts...)
+				sharedClient = yfgo.NewClient(opts...)
+				return sharedClient, nil
+			}
+
+			// Renderer
+			var rnd render.Renderer
+			if flagBySym && flagOutput != "json" {
+				return usageErrorf("--by-sym requires -o json")
+			}
+			if flagTranspose && flagOutput != "table" {
+				return usageErrorf("--transpose requires table output")
+			}
+			switch flagOutput {
+			case "table", "":
+				client, err := newClient()
+				if err != nil {
+					return err
+				}
 				rnd = render.NewTableRendererWithClient(client)
-			case "json":
-				rnd = render.NewJSONRenderer()
+			case "compact":
+				client, err := newClient()
+				if err != nil {
+					return err
+				}
+				rnd = render.NewCompactRendererWithClient(client)
+			case "line":
+				client, err := newClient()
+				if err != nil {
+					return err
+				}
+				rnd = render.NewLineRendererWithClient(client)
+			case "summary":
+				client, err := newClient()
+				if err != nil {
+					return err
+				}
+				rnd = render.NewSummaryRendererWithClient(client)
+			case "json", "jsonl":
+				client, err := newClient()
+				if err != nil {
+					return err
+				}
+				if flagOutput == "json" {
+					rnd = render.NewJSONRendererWithClient(client)
+				} else {
+					rnd = render.NewJSONLRendererWithClient(client)
+				}
 			case "syms":
 				rnd = render.NewSymsRenderer()
 			default:
-				return fmt.Errorf("unknown output: %s", flagOutput)
+				return usageErrorf("unknown output: %s", flagOutput)
 			}
 
 			// Filter
 			f, err := filter.Parse(flagFilter)
 			if err != nil {
-				return fmt.Errorf("invalid filter: %w", err)
+				return usageErrorf("invalid filter: %w", err)
+			}
+			if def := strings.TrimSpace(cfg.DefaultFilter); def != "" {
+				df, err := filter.Parse(def)
+				if err != nil {
+					return configErrorf("config default_filter %q: %w", def, err)
+				}
+				switch {
+				case !cmd.Flags().Changed("filter"):
+					f = df
+				case flagFilterAnd:
+					f = filter.All{df, f}
+				}
+			} else if flagFilterAnd {
+				return usageErrorf("--filter-and needs default_filter in config")
 			}
 
 			// List mode: list watchlist names using go-pretty list with hierarchy
 			if flagList {
-				lists, err := src.Load(cmd.Context(), spec)
+				lists, err := source.Load(cmd.Context(), src, spec)
 				if err != nil {
 					return err
 				}
@@ -409,6 +767,12 @@ func main() {
 				// Render the tree using go-pretty with connected rounded style
 				lw := list.NewWriter()
 				lw.SetStyle(list.StyleConnectedLight)
+				if flagASCII {
+					st := list.StyleConnectedLight
+					st.CharItemSingle, st.CharItemTop, st.CharItemFirst = "--", "+-", "|-"
+					st.CharItemMiddle, st.CharItemVertical, st.CharItemBottom = "|-", "|  ", "`-"
+					lw.SetStyle(st)
+				}
 				lw.SetOutputMirror(os.Stdout)
 				var walk func(prefix []string, n *node)
 				walk = func(prefix []string, n *node) {
@@ -435,6 +799,38 @@ func main() {
 				return nil
 			}
 
+			// Explain mode: trace how a column resolves for each symbol, to stderr
+			if strings.TrimSpace(flagExplain) != "" {
+				lists, err := source.Load(cmd.Context(), src, spec)
+				if err != nil {
+					return err
+				}
+				client, err := newClient()
+				if err != nil {
+					return err
+				}
+				mods := columns.RequiredModules([]string{flagExplain})
+				for _, wl := range lists {
+					if f != nil && !f.Match(wl.Name) {
+						continue
+					}
+					fmt.Fprintf(os.Stderr, "# %s\n", wl.Name)
+					for _, it := range wl.Items {
+						var m map[string]any
+						if len(mods) > 0 {
+							raw, err := client.QuoteSummary(cmd.Context(), it.Sym, mods)
+							if err != nil {
+								fmt.Fprintf(os.Stderr, "%s: fetch error: %v\n", it.Sym, err)
+							} else {
+								m = columns.RawToMap(raw)
+							}
+						}
+						columns.Explain(os.Stderr, flagExplain, it, m)
+					}
+				}
+				return nil
+			}
+
 			// Columns from config + --col-set and --columns
 			var cols []string
 			// 1) Column sets: CLI flag takes precedence, else config col_set
@@ -452,84 +848,454 @@ func main() {
 				}
 				cols = append(cols, expanded...)
 			}
-			// 2) Explicit columns: CLI flag takes precedence, else config columns
-			if strings.TrimSpace(flagCols) != "" {
-				parts := strings.Split(flagCols, ",")
-				for _, p := range parts {
-					p = strings.TrimSpace(p)
-					if p != "" {
-						already := false
-						for _, existing := range cols {
-							if existing == p {
-								already = true
-								break
-							}
-						}
-						if !already {
-							cols = append(cols, p)
-						}
+			// A --view (config name or file) replaces the sets with its ordered
+			// columns, labels and widths, defaults sort and heatmap, and adds
+			// color rules ahead of the config's
+			var viewLabels map[string]string
+			var viewWidths map[string]int
+			var viewHeatmap []string
+			if name := strings.TrimSpace(flagView); name != "" {
+				v, err := resolveView(name, cfg.Views)
+				if err != nil {
+					return err
+				}
+				viewRules, err := parseColorRules(v.ColorRules, "view "+name+" color_rules")
+				if err != nil {
+					return err
+				}
+				colorRules = append(viewRules, colorRules...)
+				cols = v.cols()
+				viewLabels, viewWidths = v.labels(), v.widths()
+				viewHeatmap = v.Heatmap
+				if !cmd.Flags().Changed("sort") && strings.TrimSpace(v.Sort) != "" {
+					flagSortBy = strings.TrimSpace(v.Sort)
+					if !cmd.Flags().Changed("desc") {
+						flagSortDesc = v.Desc
 					}
 				}
+			}
+			// 2) Explicit columns: CLI flag takes precedence, then --cols-file,
+			// else config columns. Tokens may be module globs ("price.*") or
+			// exclusions ("-vol").
+			if strings.TrimSpace(flagCols) != "" {
+				cols = columns.ApplySelection(cols, strings.Split(flagCols, ","))
+			} else if strings.TrimSpace(flagColsFile) != "" {
+				fileCols, err := readColsFile(flagColsFile)
+				if err != nil {
+					return err
+				}
+				cols = columns.ApplySelection(cols, fileCols)
 			} else if len(cfg.Columns) > 0 {
-				for _, p := range cfg.Columns {
-					p = strings.TrimSpace(p)
-					if p == "" {
-						continue
-					}
-					already := false
-					for _, existing := range cols {
-						if existing == p {
-							already = true
-							break
-						}
-					}
-					if !already {
-						cols = append(cols, p)
-					}
+				cols = columns.ApplySelection(cols, cfg.Columns)
+			}
+			// Line output defaults to a short price glance when nothing was selected
+			if flagOutput == "line" && len(cols) == 0 {
+				cols = append([]string(nil), render.DefaultLineColumns...)
+			}
+			// 3) --quick preset replaces all column selection; only the price module is fetched
+			if flagQuick {
+				cols = append([]string(nil), columns.QuickColumns...)
+			}
+			// 4) --exclude drops columns from the result, and again from each
+			// list's final columns after Prepare (YAML defaults included)
+			excludes := splitList(flagExclude)
+			excluded := map[string]bool{}
+			cols = excludeColumns(cols, excludes, excluded)
+
+			sortMissing, err := render.ParseSortMissing(flagSortMissing)
+			if err != nil {
+				return err
+			}
+
+			// Heatmap: CLI colors override config; validate before fetching
+			heatCols := splitList(flagHeatmap)
+			if !cmd.Flags().Changed("heatmap") && len(viewHeatmap) > 0 {
+				heatCols = viewHeatmap
+			}
+			heatLow, heatHigh := cfg.Heatmap.Low, cfg.Heatmap.High
+			if cmd.Flags().Changed("heatmap-low") {
+				heatLow = flagHeatmapLow
+			}
+			if cmd.Flags().Changed("heatmap-high") {
+				heatHigh = flagHeatmapHigh
+			}
+			for _, c := range []string{heatLow, heatHigh} {
+				if strings.TrimSpace(c) == "" {
+					continue
+				}
+				if _, err := render.ParseHexColor(c); err != nil {
+					return usageErrorf("heatmap: %w", err)
 				}
 			}
 
 			// Runner
-			termWidth := detectTerminalWidth()
+			term := detectTerminalSize()
 			run := &pipeline.Runner{
 				Source:   src,
 				Renderer: rnd,
 				Writer:   os.Stdout,
 			}
-			return run.Execute(cmd.Context(), spec, pipeline.ExecuteOptions{
+			outEnc, err := lookupEncoding(flagEncoding)
+			if err != nil {
+				return err
+			}
+			var out *outFile
+			if p := strings.TrimSpace(flagOutFile); p != "" {
+				out, err = openOutFile(resolvePath(p, ""))
+				if err != nil {
+					return err
+				}
+				defer out.Close()
+				run.Writer = out
+			}
+			// Terminal output is buffered so it can go through a pager once
+			// complete; --watch frames are never paged
+			var paged *bytes.Buffer
+			if out == nil && !flagNoPager && flagWatch <= 0 && !flagWatchChange && isTerminal(os.Stdout) {
+				paged = &bytes.Buffer{}
+				run.Writer = paged
+			}
+			encoded, flushEncoded := encodeWriter(run.Writer, outEnc)
+			run.Writer = encoded
+			execOpts := pipeline.ExecuteOptions{
 				Columns:     cols,
 				Filter:      f,
+				Tags:        splitList(flagTag),
+				Select:      splitList(flagSelect),
+				KeepEmpty:   flagKeepEmpty,
+				MergeLists:  flagMergeLists,
+				Dedupe:      flagDedupe,
 				Color:       !flagNoColor,
 				PrettyJSON:  flagPretty,
 				MaxColWidth: flagMaxColWidth,
-				TermWidth:   termWidth,
+				TermWidth:   term.Width,
+				NoHeader:    flagNoHeader,
 				SortBy:      flagSortBy,
 				SortDesc:    flagSortDesc,
-			})
+				SortMissing: sortMissing,
+				GroupBy:     flagGroupBy,
+				SparkDays:   flagSparkDays,
+				Concurrency: flagConcurrency,
+				MaxSymbols:  flagMaxSymbols,
+				// Syms output
+				SymsSep:         flagSymsSep,
+				SymsStripSuffix: flagSymsStrip,
+				SymsPerList:     flagSymsPerList,
+				// Heatmap
+				Heatmap:     heatCols,
+				HeatmapLow:  heatLow,
+				HeatmapHigh: heatHigh,
+				// Decimal alignment
+				AlignDecimals: flagAlignDec,
+				// View
+				ColumnLabels: viewLabels,
+				ColumnWidths: viewWidths,
+				// ASCII-only output
+				ASCII: flagASCII,
+				// YAML-only and symbol-keyed JSON
+				JSONRaw:   flagJSONRaw,
+				JSONBySym: flagBySym,
+				Notice:    func(msg string) { fmt.Fprintln(os.Stderr, "note:", msg) },
+				// Fetch retries
+				FetchRetries: flagFetchRetries,
+				BatchSize:    flagBatchSize,
+				// Per-list item limit
+				Limit: flagLimit,
+				// Currency conversion
+				ConvertTo: flagConvertTo,
+				FXRates:   cfg.FXRates,
+				// Movers
+				MoverThreshold: flagMoverThresh,
+				OnlyMovers:     flagOnlyMovers,
+				// Terminal-width fitting
+				AutoFit: flagAutoFit,
+				// Config color rules
+				ColorRules: colorRules,
+				// Sideways table
+				Transpose: flagTranspose,
+				// Table colors
+				Theme: strings.ToLower(strings.TrimSpace(flagTheme)),
+				// Sidecar fields
+				AnnotateOverride: flagAnnotateOver,
+			}
+			if flagAnnotate != "" {
+				execOpts.Annotate = resolvePath(flagAnnotate, "")
+			}
+			// Progress goes to stderr, only when both it and stdout are
+			// terminals and color is on, so piped or redirected runs stay clean
+			if !flagNoColor && out == nil && isTerminal(os.Stderr) && isTerminal(os.Stdout) {
+				execOpts.Progress = progressLine{w: os.Stderr}.Update
+			}
+			// Alerts are checked after each render against the same lists
+			alerts := make([]render.Alert, 0, len(flagAlerts))
+			for _, expr := range flagAlerts {
+				a, err := render.ParseAlert(expr)
+				if err != nil {
+					return err
+				}
+				alerts = append(alerts, a)
+			}
+			var dispatcher *alertDispatcher
+			if flagNotify {
+				if flagWatch <= 0 || len(alerts) == 0 {
+					return usageErrorf("--notify requires --watch and at least one --alert")
+				}
+				dispatcher = newAlertDispatcher(newNotifier())
+			}
+			if srcDesc == "" {
+				srcDesc = fmt.Sprint(spec)
+			}
+			// Only syms and --json-raw output skip fetching Yahoo data
+			fetchesQuotes := flagOutput != "syms" && !(flagJSONRaw && (flagOutput == "json" || flagOutput == "jsonl"))
+			if flagConcurrency < 1 {
+				return usageErrorf("--concurrency must be at least 1")
+			}
+			if flagFetchRetries < 0 {
+				return usageErrorf("--fetch-retries must be >= 0")
+			}
+			if flagLimit < 0 {
+				return usageErrorf("--limit must be >= 0")
+			}
+			if flagBatchSize < 0 {
+				return usageErrorf("--batch-size must be >= 0")
+			}
+			if flagMoverThresh < 0 {
+				return usageErrorf("--mover-threshold must be >= 0")
+			}
+			renderOnce := func(ctx context.Context) error {
+				if flagTimeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, flagTimeout)
+					defer cancel()
+				}
+				lists, err := run.Prepare(ctx, spec, execOpts)
+				if err != nil {
+					return err
+				}
+				if len(excludes) > 0 {
+					for i := range lists {
+						lists[i].Columns = excludeColumns(lists[i].Columns, excludes, excluded)
+					}
+					for _, n := range excludes {
+						if !excluded[n] {
+							fmt.Fprintf(os.Stderr, "warning: --exclude: no column %q\n", n)
+							excluded[n] = true // warn once under --watch
+						}
+					}
+				}
+				for _, a := range alerts {
+					if !a.Known(lists) {
+						return usageErrorf("--alert %s: no column or item field %q", a.Expr, a.Column)
+					}
+				}
+				opts := execOpts
+				if flagJSONMeta && !flagStable {
+					opts.JSONMeta = &render.JSONMeta{GeneratedAt: time.Now(), Source: srcDesc}
+				}
+				if flagWarm && fetchesQuotes && !flagOffline && !cacheDisabled {
+					client, err := newClient()
+					if err != nil {
+						return err
+					}
+					if errs := render.WarmCache(ctx, client, lists, opts.RenderOptions()); len(errs) > 0 {
+						fmt.Fprintf(os.Stderr, "warm: %d symbol(s) failed, e.g. %v\n", len(errs), errs[0])
+					}
+				}
+				var fetchErrs []render.FetchError
+				if flagReportErrors {
+					opts.FetchFailed = func(e render.FetchError) { fetchErrs = append(fetchErrs, e) }
+				}
+				if err := run.Render(ctx, lists, opts); err != nil {
+					return err
+				}
+				if s := render.SummarizeFetchErrors(fetchErrs); s != "" {
+					fmt.Fprintln(os.Stderr, s)
+				}
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return &render.RenderError{Err: fmt.Errorf("--timeout %s reached; cells not fetched by then were left blank", flagTimeout)}
+				}
+				if len(alerts) == 0 {
+					return nil
+				}
+				client, err := newClient()
+				if err != nil {
+					return err
+				}
+				hits := render.EvaluateAlerts(ctx, client, lists, alerts, opts.RenderOptions())
+				for _, h := range hits {
+					fmt.Fprintf(os.Stderr, "ALERT %s %s: %s\n", h.Sym, h.Alert.Expr, h.Display)
+				}
+				if dispatcher != nil {
+					if err := dispatcher.Dispatch(hits); err != nil {
+						fmt.Fprintln(os.Stderr, "notify:", err)
+					}
+				}
+				if flagAlertExit && len(hits) > 0 && flagWatch <= 0 && !flagWatchChange {
+					return fmt.Errorf("%d alert(s) triggered", len(hits))
+				}
+				return nil
+			}
+			if flagWatchChange {
+				path, ok := spec.(string)
+				if flagWatch > 0 {
+					return usageErrorf("--watch-on-change cannot be combined with --watch")
+				}
+				if !ok || path == "-" || flagSource == "git" || flagSource == "db" ||
+					strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+					return usageErrorf("--watch-on-change needs a local yaml, csv or json file or directory")
+				}
+				interactive := out == nil && isTerminal(os.Stdout)
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				err = watchOnChange(ctx, path, func(ctx context.Context) error {
+					if interactive {
+						writeWatchHeader(run.Writer, "On change", time.Now())
+					}
+					return renderOnce(ctx)
+				})
+			} else if flagWatch > 0 {
+				if spec == "-" {
+					return usageErrorf("--watch cannot re-read a watchlist from stdin")
+				}
+				var gate func(context.Context) bool
+				if flagMarketHours {
+					gate, err = marketGate(cfg.MarketHours.TZ, cfg.MarketHours.Open, cfg.MarketHours.Close, src, spec, newClient)
+					if err != nil {
+						return err
+					}
+				}
+				// On a terminal each refresh replaces the last; piped or
+				// --out-file output is appended without headers
+				interactive := out == nil && isTerminal(os.Stdout)
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				err = watchLoop(ctx, flagWatch, func(ctx context.Context) error {
+					if interactive {
+						writeWatchHeader(run.Writer, "Every "+flagWatch.String(), time.Now())
+					}
+					return renderOnce(ctx)
+				}, gate)
+			} else {
+				err = renderOnce(cmd.Context())
+				if err != nil && paged != nil {
+					// Show what was rendered before failing (e.g. --alert-exit)
+					_ = flushEncoded()
+					_, _ = os.Stdout.Write(paged.Bytes())
+				}
+			}
+			if err != nil {
+				return err
+			}
+			if err := flushEncoded(); err != nil {
+				return err
+			}
+			if paged != nil {
+				return pageOutput(paged.Bytes(), flagPager, term.Height)
+			}
+			if out != nil {
+				return out.Close()
+			}
+			return nil
 		},
 	}
 
-	rootCmd.Flags().StringVar(&flagSource, "source", "yaml", "data source: yaml|db")
-	rootCmd.Flags().StringVar(&flagDBDSN, "db-dsn", "", "database DSN for db source")
-	rootCmd.Flags().StringVarP(&flagOutput, "output", "o", "table", "output format: table|json|syms")
+	rootCmd.Flags().StringVar(&flagSource, "source", "yaml", "data source: yaml|csv|json|git|db")
+	rootCmd.Flags().StringVar(&flagGitRepo, "repo", "", "git repository URL for git source")
+	rootCmd.Flags().StringVar(&flagGitPath, "path", "", "file or directory inside the git repository")
+	rootCmd.Flags().DurationVar(&flagGitTTL, "git-ttl", source.DefaultGitTTL, "how long a cached git checkout is reused before fetching")
+	rootCmd.Flags().StringVar(&flagDBDSN, "db-dsn", "", "SQLite DSN (file path) for db source")
+	rootCmd.Flags().StringVarP(&flagOutput, "output", "o", "table", "output format: table|compact|line|summary|json|jsonl|syms")
+	rootCmd.Flags().StringVar(&flagOutFile, "out-file", "", "write output to a file (parent dirs created, existing file truncated)")
+	rootCmd.Flags().StringVar(&flagEncoding, "encoding", "utf-8", "output encoding: utf-8|shift-jis|euc-jp (unmappable characters become '?')")
 	rootCmd.Flags().BoolVar(&flagNoColor, "no-color", false, "disable color output")
+	rootCmd.Flags().BoolVar(&flagColor, "color", false, "force color output even when stdout is not a terminal or NO_COLOR is set")
+	rootCmd.Flags().BoolVar(&flagAlignDec, "align-decimals", false, "pad numeric columns so decimal points line up")
+	rootCmd.Flags().BoolVar(&flagNoHeader, "no-header", false, "omit the column header row in table output")
+	rootCmd.Flags().BoolVar(&flagASCII, "ascii", false, "ASCII-only output: plain borders, |-based --list tree, ASCII sparklines; other non-ASCII text becomes '?'")
+	rootCmd.Flags().StringVar(&flagSymsSep, "syms-sep", ",", "separator between symbols for syms output")
+	rootCmd.Flags().StringVar(&flagSymsStrip, "syms-strip-suffix", ".T", "suffix stripped from symbols for syms output (empty keeps symbols as-is)")
+	rootCmd.Flags().BoolVar(&flagSymsPerList, "syms-per-list", false, "syms output: print one line per list prefixed by its name")
 	rootCmd.Flags().BoolVarP(&flagPretty, "pretty", "p", false, "pretty-print JSON output")
+	rootCmd.Flags().BoolVar(&flagBySym, "by-sym", false, "JSON output as one object mapping each symbol to its column values across all lists")
+	rootCmd.Flags().BoolVar(&flagJSONMeta, "json-meta", false, "wrap JSON output with generated_at and source fields")
+	rootCmd.Flags().BoolVar(&flagJSONRaw, "json-raw", false, "JSON/JSONL items carry only their YAML fields; column values are not fetched")
+	rootCmd.Flags().BoolVar(&flagStable, "stable", false, "suppress run-specific output such as --json-meta for reproducible snapshots")
 	rootCmd.Flags().StringVarP(&flagCols, "cols", "c", "", "comma-separated columns to display")
+	rootCmd.Flags().StringVar(&flagExclude, "exclude", "", "comma-separated columns to drop from the final selection (aliases allowed)")
+	rootCmd.Flags().StringVar(&flagColsFile, "cols-file", "", "read columns from a file (one per line or comma-separated, # comments); --cols overrides it")
+	rootCmd.Flags().StringVar(&flagView, "view", "", "view preset: a name under views in config, or a YAML view file (columns, labels, widths, sort, heatmap, color rules)")
 	rootCmd.Flags().StringVarP(&flagColSet, "col-set", "C", "", "comma-separated column sets: price,assetProfile,yaml")
-	rootCmd.Flags().StringVar(&flagConfigPath, "config", "", "path to config file (default: $WL_HOME/config.yaml or ~/.wl/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&flagConfigPath, "config", "", "path to config file (default: $WL_HOME/config.yaml or ~/.wl/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&flagConfigDir, "config-dir", "", "directory holding config.yaml, separate from WL home (default: $WL_CONFIG_DIR or WL home)")
 	rootCmd.Flags().StringVarP(&flagFilter, "filter", "f", "", "filter watchlists by name: substring (ci), name[,name...], glob, or /regex/")
+	rootCmd.Flags().BoolVar(&flagFilterAnd, "filter-and", false, "apply --filter on top of default_filter from config instead of replacing it")
+	rootCmd.Flags().StringVarP(&flagTag, "tag", "t", "", "keep only items whose tags include any of these (comma-separated, case-insensitive)")
+	rootCmd.Flags().StringVar(&flagSelect, "select", "", "keep only these symbols across all lists (comma-separated; 7203 also matches 7203.T)")
+	rootCmd.Flags().BoolVar(&flagDedupe, "dedupe", false, "drop repeated symbols within each list (case-insensitive; first wins, later fields fill gaps)")
+	rootCmd.Flags().BoolVar(&flagMergeLists, "merge-lists", false, "combine lists with the same name, de-duplicating symbols (first wins)")
+	rootCmd.Flags().BoolVar(&flagKeepEmpty, "keep-empty", false, "keep lists left empty by --tag/--select")
 	rootCmd.Flags().BoolVar(&flagList, "list", false, "list watchlist names only")
 	rootCmd.Flags().BoolVarP(&flagListColumns, "list-cols", "l", false, "list available column names")
 	rootCmd.Flags().BoolVarP(&flagListColSets, "list-col-sets", "L", false, "list column sets in compact form (built-in + config)")
+	rootCmd.Flags().BoolVarP(&flagQuick, "quick", "q", false, "quick glance: only sym,name,price,chg% (fetches just the price module)")
+	rootCmd.Flags().IntVar(&flagMaxSymbols, "max-symbols", 0, "error before fetching if the lists hold more symbols than this (0 = unlimited)")
+	rootCmd.Flags().StringVar(&flagTheme, "theme", render.DefaultTheme, "table color theme: "+strings.Join(render.ThemeNames(), "|"))
+	rootCmd.Flags().BoolVar(&flagTranspose, "transpose", false, fmt.Sprintf("table with a row per column and a column per symbol, for deep dives on up to %d symbols per list", render.TransposeMaxSymbols))
+	rootCmd.Flags().StringVar(&flagAnnotate, "annotate", "", "merge per-symbol fields from a sidecar YAML (SYM: {field: value}) into every list's items")
+	rootCmd.Flags().BoolVar(&flagAnnotateOver, "annotate-override", false, "let --annotate fields replace fields the items already have (default: item fields win)")
+	rootCmd.Flags().BoolVar(&flagAutoFit, "auto-fit", false, "shrink the widest text columns so the table fits the terminal width (falls back to --max-col-width when the width is unknown)")
 	rootCmd.Flags().IntVar(&flagMaxColWidth, "max-col-width", 40, "max width per column before wrapping (characters)")
+	rootCmd.Flags().StringVar(&flagAsOfFormat, "as-of-format", "", "Go time layout for the as_of column, e.g. '2006-01-02 15:04' (default: age such as '3m ago')")
+	rootCmd.Flags().Float64Var(&flagMoverThresh, "mover-threshold", 0, "leave table rows whose chg% is within ±N percent uncolored (e.g. 0.5); 0 colors every row")
+	rootCmd.Flags().BoolVar(&flagOnlyMovers, "only-movers", false, "drop table rows whose chg% is within ±--mover-threshold percent or missing")
+	rootCmd.Flags().StringVar(&flagConvertTo, "convert-to", "", "convert prices, market cap and other amounts to this currency (e.g. USD) using Yahoo FX rates or fx_rates from config")
+	rootCmd.Flags().StringVar(&flagDateFormat, "date-format", columns.DateFormat, "Go time layout for date columns (earnings_date, div_date)")
+	rootCmd.Flags().IntVar(&flagPercentDec, "percent-decimals", columns.PercentDecimals, "decimals in percentages wl computes (range52, off_high%, computed % columns); Yahoo values keep theirs")
 	rootCmd.Flags().BoolVar(&flagCacheDisable, "cache-disable", false, "disable Yahoo Finance client caching")
+	rootCmd.Flags().BoolVar(&flagOffline, "offline", false, "render only from the persistent cache, never hitting the network (uncached cells stay blank)")
 	rootCmd.Flags().DurationVar(&flagCacheTTL, "cache-ttl", 0, "override Yahoo Finance cache TTL (e.g. 2m); 0 keeps library default")
-	rootCmd.Flags().StringVar(&flagCacheDir, "cache-dir", "", "use a directory for persistent Yahoo Finance cache entries")
+	rootCmd.PersistentFlags().StringVar(&flagCacheDir, "cache-dir", "", "use a directory for persistent Yahoo Finance cache entries")
+	rootCmd.Flags().StringVar(&flagExplain, "explain", "", "trace how a column resolves for each symbol (printed to stderr)")
 	// Sorting
-	rootCmd.Flags().StringVarP(&flagSortBy, "sort", "s", "", "sort rows by column (handles text, numbers, formatted values, and chg%)")
+	rootCmd.Flags().StringVarP(&flagSortBy, "sort", "s", "", "sort rows by columns, e.g. sector,mktcap:desc (handles text, numbers, formatted values, and chg%)")
 	rootCmd.Flags().BoolVar(&flagSortDesc, "desc", false, "sort in descending order (default asc)")
+	rootCmd.Flags().StringVar(&flagSortMissing, "sort-missing", render.SortMissingLast, "where rows missing a sort value go: first|last")
+	rootCmd.Flags().StringVar(&flagGroupBy, "group-by", "", "group table rows by a column value (sort applies within groups)")
+	rootCmd.Flags().IntVar(&flagLimit, "limit", 0, "show at most N items per list, after sorting (0 = no limit)")
+	// Alerts
+	rootCmd.Flags().StringArrayVar(&flagAlerts, "alert", nil, "print symbols breaching a threshold to stderr, e.g. 'chg%<-5' (repeatable)")
+	rootCmd.Flags().BoolVar(&flagAlertExit, "alert-exit", false, "exit non-zero when any --alert triggers")
+	rootCmd.Flags().BoolVar(&flagNotify, "notify", false, "with --watch, send a desktop notification when an --alert starts triggering")
+	// Watch
+	rootCmd.Flags().DurationVar(&flagWatch, "watch", 0, "re-render every interval (e.g. 30s) until interrupted")
+	rootCmd.Flags().BoolVar(&flagPager, "pager", false, "page terminal output through $PAGER (or less) even when it fits the screen")
+	rootCmd.Flags().BoolVar(&flagNoPager, "no-pager", false, "never page terminal output (by default output taller than the terminal is paged)")
+	rootCmd.Flags().BoolVar(&flagWatchChange, "watch-on-change", false, "re-render whenever the watchlist file or directory changes on disk, until interrupted")
+	rootCmd.Flags().BoolVar(&flagMarketHours, "market-hours-only", false, "with --watch, skip refreshes while markets are closed")
+	rootCmd.Flags().IntVar(&flagSparkDays, "spark-days", render.DefaultSparkDays, "number of daily closes drawn by the spark column")
+	rootCmd.Flags().BoolVar(&flagWarm, "warm", false, "fetch all symbols concurrently before rendering so the render loop hits the cache")
+	rootCmd.Flags().StringVar(&flagCPUProfile, "cpuprofile", "", "write a pprof CPU profile of the run to this file")
+	rootCmd.Flags().StringVar(&flagMemProfile, "memprofile", "", "write a pprof heap profile to this file when the run ends")
+	rootCmd.Flags().DurationVar(&flagTimeout, "timeout", 0, "give up fetching after this long (e.g. 30s), rendering unfetched cells blank; with --watch it applies to each refresh (0 = no limit)")
+	rootCmd.Flags().BoolVar(&flagReportErrors, "report-errors", false, "after rendering, summarize symbols whose fetch failed on stderr, e.g. '2 symbols failed: FOO (404), BAR (timeout)'")
+	rootCmd.Flags().IntVar(&flagFetchRetries, "fetch-retries", 2, "retries for transient Yahoo errors (network, 429, 5xx) per symbol, with exponential backoff")
+	rootCmd.Flags().IntVar(&flagBatchSize, "batch-size", 0, "fetch price-only columns (e.g. --quick) through Yahoo's multi-symbol quote endpoint, N symbols per request; 0 fetches per symbol")
+	rootCmd.Flags().IntVar(&flagConcurrency, "concurrency", render.DefaultConcurrency, "maximum symbols fetched at once when rendering or warming")
+	// Heatmap
+	rootCmd.Flags().StringVar(&flagHeatmap, "heatmap", "", "comma-separated columns to color on a low-to-high gradient")
+	rootCmd.Flags().StringVar(&flagHeatmapLow, "heatmap-low", render.DefaultHeatmapLow, "heatmap color for the lowest value (#rrggbb)")
+	rootCmd.Flags().StringVar(&flagHeatmapHigh, "heatmap-high", render.DefaultHeatmapHigh, "heatmap color for the highest value (#rrggbb)")
+
+	rootCmd.AddCommand(newCacheCmd(&flagConfigPath, &flagConfigDir, &flagCacheDir))
+	rootCmd.AddCommand(newValidateCmd(&flagConfigPath, &flagConfigDir))
+	rootCmd.AddCommand(newInitCmd(&flagConfigPath, &flagConfigDir))
+	rootCmd.AddCommand(newExportCmd(&flagConfigPath, &flagConfigDir))
+	rootCmd.AddCommand(newDiffCmd())
+	registerCompletions(rootCmd, &flagConfigPath, &flagConfigDir)
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error { return &usageError{err: err} })
+	rootCmd.SetHelpTemplate(rootCmd.HelpTemplate() + "\n" + exitCodesHelp + "\n")
 
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
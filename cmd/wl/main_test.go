@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha1" // #nosec G505 -- matches yf-go's cache file naming
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMain lets tests run the wl command in a subprocess: the test binary
+// re-executed with WL_TEST_MAIN=1 behaves as wl itself.
+func TestMain(m *testing.M) {
+	if os.Getenv("WL_TEST_MAIN") == "1" {
+		main()
+		os.Exit(exitOK)
+	}
+	os.Exit(m.Run())
+}
+
+// run is the outcome of one wl invocation.
+type run struct {
+	stdout, stderr string
+	code           int
+}
+
+// runWL runs wl with args in home, which is also WL_HOME and HOME so no
+// user config leaks in; env adds "KEY=value" entries.
+func runWL(t *testing.T, home string, env []string, args ...string) run {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Dir = home
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "WL_") && !strings.HasPrefix(kv, "NO_COLOR=") {
+			cmd.Env = append(cmd.Env, kv)
+		}
+	}
+	cmd.Env = append(cmd.Env, "WL_TEST_MAIN=1", "WL_HOME="+home, "HOME="+home)
+	cmd.Env = append(cmd.Env, env...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	var exit *exec.ExitError
+	if err != nil && !errors.As(err, &exit) {
+		t.Fatal(err)
+	}
+	return run{stdout: stdout.String(), stderr: stderr.String(), code: cmd.ProcessState.ExitCode()}
+}
+
+// writeFile writes data to name under dir, creating parent directories.
+func writeFile(t *testing.T, dir, name, data string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// cacheQuote stores a quoteSummary module for sym in a yf-go file cache
+// at dir, for runs with --offline --cache-dir dir.
+func cacheQuote(t *testing.T, dir, sym, module string, value any) {
+	t.Helper()
+	payload, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := json.Marshal(struct {
+		Payload  []byte    `json:"payload"`
+		StoredAt time.Time `json:"storedAt"`
+	}{payload, time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha1.Sum([]byte("quotesummary-module:" + sym + ":" + module)) // #nosec G401 -- cache key, not security
+	writeFile(t, dir, hex.EncodeToString(sum[:])+".json", string(rec))
+}
+
+// priceModule is a minimal price module for cacheQuote.
+func priceModule(price, chgPct float64) map[string]any {
+	return map[string]any{
+		"currency":                   "USD",
+		"regularMarketPrice":         map[string]any{"raw": price, "fmt": strconv.FormatFloat(price, 'f', 2, 64)},
+		"regularMarketChangePercent": map[string]any{"raw": chgPct / 100, "fmt": strconv.FormatFloat(chgPct, 'f', 2, 64) + "%"},
+	}
+}
+
+func TestQuickIgnoresConfigColumns(t *testing.T) {
+	home := t.TempDir()
+	writeFile(t, home, "config.yaml", "columns: [sym, sector, pe]\n")
+	writeFile(t, home, "us.yaml", "watchlist:\n  - sym: AAPL\n    name: Apple\n")
+	cacheQuote(t, home+"/cache", "AAPL", "price", priceModule(180, 1.25))
+	r := runWL(t, home, nil, "--offline", "--cache-dir", "cache", "--quick", "us.yaml")
+	if r.code != exitOK {
+		t.Fatalf("exit %d: %s", r.code, r.stderr)
+	}
+	if got := strings.Fields(strings.Split(r.stdout, "\n")[0]); strings.Join(got, " ") != "SYM NAME PRICE CHG%" {
+		t.Errorf("--quick header = %v", got)
+	}
+	if !strings.Contains(r.stdout, "180.00") || !strings.Contains(r.stdout, "1.25%") {
+		t.Errorf("--quick row:\n%s", r.stdout)
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	home := t.TempDir()
+	writeFile(t, home, "us.yaml", "watchlist:\n  - sym: AAPL\n  - sym: MSFT\n")
+	cacheQuote(t, home+"/cache", "AAPL", "price", priceModule(180, -6.5))
+	cacheQuote(t, home+"/cache", "MSFT", "price", priceModule(400, 1.2))
+	base := []string{"--offline", "--cache-dir", "cache", "-c", "sym,price,chg%", "us.yaml"}
+
+	r := runWL(t, home, nil, append(base, "--alert", "chg%<-5", "--alert", "price>1000", "--alert-exit")...)
+	if r.code != exitFailed {
+		t.Errorf("triggered --alert-exit: exit %d, want %d", r.code, exitFailed)
+	}
+	if r.stderr != "ALERT AAPL chg%<-5: -6.50%\nError: 1 alert(s) triggered\n" {
+		t.Errorf("stderr = %q", r.stderr)
+	}
+	if !strings.Contains(r.stdout, "MSFT") {
+		t.Errorf("table not rendered before alerts:\n%s", r.stdout)
+	}
+
+	r = runWL(t, home, nil, append(base, "--alert", "chg%<-10", "--alert-exit")...)
+	if r.code != exitOK || strings.Contains(r.stderr, "ALERT") {
+		t.Errorf("untriggered: exit %d, stderr %q", r.code, r.stderr)
+	}
+	// without --alert-exit a breach is only reported
+	if r = runWL(t, home, nil, append(base, "--alert", "chg%<-5")...); r.code != exitOK || !strings.Contains(r.stderr, "ALERT AAPL") {
+		t.Errorf("report only: exit %d, stderr %q", r.code, r.stderr)
+	}
+	// a misspelled column is a usage error rather than an alert that never fires
+	if r = runWL(t, home, nil, append(base, "--alert", "chgpct<-5")...); r.code != exitUsage || !strings.Contains(r.stderr, `no column or item field "chgpct"`) {
+		t.Errorf("unknown column: exit %d, stderr %q", r.code, r.stderr)
+	}
+}
+
+func TestJSONMetaStable(t *testing.T) {
+	home := t.TempDir()
+	path := writeFile(t, home, "us.yaml", "watchlist:\n  - sym: AAPL\n")
+	base := []string{"--offline", "--cache-dir", "cache", "-o", "json", "-c", "sym", "--json-meta", "us.yaml"}
+
+	r := runWL(t, home, nil, base...)
+	var meta struct {
+		GeneratedAt string `json:"generated_at"`
+		Source      string `json:"source"`
+		Lists       []any  `json:"lists"`
+	}
+	if err := json.Unmarshal([]byte(r.stdout), &meta); err != nil {
+		t.Fatalf("exit %d: %v\n%s%s", r.code, err, r.stdout, r.stderr)
+	}
+	if _, err := time.Parse(time.RFC3339, meta.GeneratedAt); err != nil {
+		t.Errorf("generated_at %q: %v", meta.GeneratedAt, err)
+	}
+	if !strings.Contains(meta.Source, filepath.Base(path)) || len(meta.Lists) != 1 {
+		t.Errorf("source %q, lists %v", meta.Source, meta.Lists)
+	}
+
+	r = runWL(t, home, nil, append(base, "--stable")...)
+	if strings.Contains(r.stdout, "generated_at") || !strings.HasPrefix(r.stdout, "[") {
+		t.Errorf("--stable kept the metadata:\n%s", r.stdout)
+	}
+}
+
+func TestListTreeASCII(t *testing.T) {
+	home := t.TempDir()
+	writeFile(t, home, "lists/us/tech.yaml", "watchlist:\n  - sym: AAPL\n")
+	writeFile(t, home, "lists/us/banks.yaml", "watchlist:\n  - sym: JPM\n")
+	writeFile(t, home, "lists/jp.yaml", "watchlist:\n  - sym: 7203.T\n")
+
+	r := runWL(t, home, nil, "--list", "lists")
+	if r.code != exitOK || !strings.ContainsAny(r.stdout, "├└─") {
+		t.Fatalf("tree without --ascii: exit %d\n%s%s", r.code, r.stdout, r.stderr)
+	}
+	r = runWL(t, home, nil, "--list", "--ascii", "lists")
+	if r.code != exitOK {
+		t.Fatalf("exit %d: %s", r.code, r.stderr)
+	}
+	for i, c := range []byte(r.stdout) {
+		if c >= 0x80 {
+			t.Fatalf("non-ASCII byte at %d:\n%s", i, r.stdout)
+		}
+	}
+	for _, want := range []string{"JP", "US", "BANKS", "TECH", "|-", "`-"} {
+		if !strings.Contains(r.stdout, want) {
+			t.Errorf("--ascii tree missing %q:\n%s", want, r.stdout)
+		}
+	}
+}
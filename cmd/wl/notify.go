@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/render"
+)
+
+// notifier sends a desktop notification.
+type notifier interface {
+	Notify(title, body string) error
+}
+
+// execNotifier shells out to the platform's notification tool.
+type execNotifier struct {
+	name string
+	args func(title, body string) []string
+}
+
+func (n execNotifier) Notify(title, body string) error {
+	return exec.Command(n.name, n.args(title, body)...).Run()
+}
+
+// nopNotifier is used where no notification tool is available.
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(string, string) error { return nil }
+
+// newNotifier picks osascript on macOS, notify-send elsewhere on unix and a
+// PowerShell toast on Windows, falling back to a no-op when the tool is missing.
+func newNotifier() notifier {
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("osascript"); err == nil {
+			return execNotifier{name: "osascript", args: func(title, body string) []string {
+				return []string{"-e", fmt.Sprintf("display notification %s with title %s", appleQuote(body), appleQuote(title))}
+			}}
+		}
+	case "windows":
+		if _, err := exec.LookPath("powershell"); err == nil {
+			return execNotifier{name: "powershell", args: func(title, body string) []string {
+				script := "[reflection.assembly]::loadwithpartialname('System.Windows.Forms') | Out-Null;" +
+					"$n = New-Object System.Windows.Forms.NotifyIcon;" +
+					"$n.Icon = [System.Drawing.SystemIcons]::Information;" +
+					"$n.Visible = $true;" +
+					fmt.Sprintf("$n.ShowBalloonTip(5000, %s, %s, 'Info')", psQuote(title), psQuote(body))
+				return []string{"-NoProfile", "-Command", script}
+			}}
+		}
+	default:
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			return execNotifier{name: "notify-send", args: func(title, body string) []string {
+				return []string{title, body}
+			}}
+		}
+	}
+	return nopNotifier{}
+}
+
+func appleQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// alertDispatcher notifies once when an alert starts triggering; a symbol
+// must stop breaching before it can notify again, so a watch loop does not
+// repeat the same notification every tick.
+type alertDispatcher struct {
+	n      notifier
+	active map[string]bool
+}
+
+func newAlertDispatcher(n notifier) *alertDispatcher {
+	return &alertDispatcher{n: n, active: map[string]bool{}}
+}
+
+// Dispatch sends a notification for each newly triggered hit and returns the
+// first delivery error, if any.
+func (d *alertDispatcher) Dispatch(hits []render.AlertHit) error {
+	now := make(map[string]bool, len(hits))
+	var firstErr error
+	for _, h := range hits {
+		key := h.List + "\x00" + h.Sym + "\x00" + h.Alert.Expr
+		now[key] = true
+		if d.active[key] {
+			continue
+		}
+		if err := d.n.Notify("wl alert: "+h.Sym, fmt.Sprintf("%s: %s", h.Alert.Expr, h.Display)); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	d.active = now
+	return firstErr
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/render"
+)
+
+// fakeNotifier records notifications and fails with err.
+type fakeNotifier struct {
+	sent []string
+	err  error
+}
+
+func (f *fakeNotifier) Notify(title, body string) error {
+	f.sent = append(f.sent, title+" | "+body)
+	return f.err
+}
+
+func hit(list, sym, expr, display string) render.AlertHit {
+	return render.AlertHit{Alert: render.Alert{Expr: expr}, List: list, Sym: sym, Display: display}
+}
+
+func TestAlertDispatcher(t *testing.T) {
+	n := &fakeNotifier{}
+	d := newAlertDispatcher(n)
+	ticks := [][]render.AlertHit{
+		{hit("us", "AAPL", "chg%<-5", "-6.50%")},
+		{hit("us", "AAPL", "chg%<-5", "-7.00%"), hit("us", "MSFT", "pe>40", "41.2")}, // AAPL still breaching
+		{hit("us", "MSFT", "pe>40", "42.0")},                                         // AAPL recovered
+		{hit("us", "AAPL", "chg%<-5", "-5.50%"), hit("us", "MSFT", "pe>40", "42.0")}, // AAPL breaches again
+		nil,
+	}
+	for _, hits := range ticks {
+		if err := d.Dispatch(hits); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{
+		"wl alert: AAPL | chg%<-5: -6.50%",
+		"wl alert: MSFT | pe>40: 41.2",
+		"wl alert: AAPL | chg%<-5: -5.50%",
+	}
+	if !reflect.DeepEqual(n.sent, want) {
+		t.Errorf("sent %q, want %q", n.sent, want)
+	}
+
+	failing := &fakeNotifier{err: errors.New("no display")}
+	d = newAlertDispatcher(failing)
+	if err := d.Dispatch([]render.AlertHit{hit("us", "AAPL", "chg%<-5", "-6%"), hit("jp", "AAPL", "chg%<-5", "-6%")}); err == nil || len(failing.sent) != 2 {
+		t.Errorf("err %v after %d sends, want the first error after trying both lists", err, len(failing.sent))
+	}
+	if err := (nopNotifier{}).Notify("t", "b"); err != nil {
+		t.Errorf("nopNotifier: %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// outFile is an output destination that names its path in write errors.
+type outFile struct {
+	f    *os.File
+	path string
+}
+
+// openOutFile creates (or truncates) path, creating parent directories as needed.
+func openOutFile(path string) (*outFile, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create output dir for %s: %w", path, err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open output file: %w", err)
+	}
+	return &outFile{f: f, path: path}, nil
+}
+
+func (o *outFile) Write(p []byte) (int, error) {
+	n, err := o.f.Write(p)
+	if err != nil {
+		return n, fmt.Errorf("write %s: %w", o.path, err)
+	}
+	return n, nil
+}
+
+func (o *outFile) Close() error {
+	if err := o.f.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", o.path, err)
+	}
+	return nil
+}
+
+var _ io.WriteCloser = (*outFile)(nil)
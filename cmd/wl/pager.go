@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+)
+
+// pageOutput writes rendered output to stdout, through a pager when force is
+// set or when it has more lines than fit in a terminal of the given height
+// (0 = unknown, never auto-pages). The pager is $PAGER, else less, else a
+// simple built-in one.
+func pageOutput(buf []byte, force bool, height int) error {
+	lines := bytes.Count(buf, []byte("\n"))
+	if !force && (height <= 0 || lines < height) {
+		_, err := os.Stdout.Write(buf)
+		return err
+	}
+	cmdline := strings.TrimSpace(os.Getenv("PAGER"))
+	if cmdline == "" {
+		if _, err := exec.LookPath("less"); err == nil {
+			cmdline = "less"
+		}
+	}
+	if cmdline == "" {
+		if height <= 0 || !isTerminal(os.Stdin) {
+			_, err := os.Stdout.Write(buf)
+			return err
+		}
+		return simplePager(os.Stdout, os.Stdin, buf, height)
+	}
+	args := strings.Fields(cmdline)
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = bytes.NewReader(buf)
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	// Like git: keep colors, and quit at once when everything fits
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		pager.Env = append(os.Environ(), "LESS=FRX")
+	}
+	// Ctrl-C belongs to the pager (less uses it to stop a search)
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	if err := pager.Run(); err != nil {
+		return fmt.Errorf("pager %s: %w", args[0], err)
+	}
+	return nil
+}
+
+// simplePager shows buf one screen at a time, advancing on Enter and
+// stopping on q.
+func simplePager(w io.Writer, keys io.Reader, buf []byte, height int) error {
+	lines := strings.SplitAfter(string(buf), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	in := bufio.NewReader(keys)
+	page := height - 1
+	for start := 0; start < len(lines); start += page {
+		end := start + page
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if _, err := io.WriteString(w, strings.Join(lines[start:end], "")); err != nil {
+			return err
+		}
+		if end == len(lines) {
+			return nil
+		}
+		fmt.Fprintf(w, "-- %d/%d lines, Enter for more, q to quit --", end, len(lines))
+		answer, err := in.ReadString('\n')
+		fmt.Fprint(w, "\r\x1b[1A\x1b[K")
+		if err != nil || strings.HasPrefix(strings.TrimSpace(answer), "q") {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// progressLine draws a one-line fetch progress bar, redrawn in place with
+// carriage returns and cleared when a list's fetches finish so rendered
+// rows never share the terminal line with it.
+type progressLine struct {
+	w io.Writer
+}
+
+const progressBarWidth = 20
+
+// Update implements render.RenderOptions.Progress.
+func (p progressLine) Update(done, total int) {
+	if total <= 0 || done >= total {
+		fmt.Fprint(p.w, "\r\x1b[K")
+		return
+	}
+	filled := done * progressBarWidth / total
+	fmt.Fprintf(p.w, "\r\x1b[Kfetching [%s%s] %d/%d", strings.Repeat("#", filled), strings.Repeat(".", progressBarWidth-filled), done, total)
+}
@@ -0,0 +1,12 @@
+package main
+
+// termSize is stdout's terminal size in cells; 0 means unknown.
+type termSize struct {
+	Width  int
+	Height int
+}
+
+// detectTerminalSize returns the terminal width and height together.
+func detectTerminalSize() termSize {
+	return termSize{Width: detectTerminalWidth(), Height: detectTerminalHeight()}
+}
@@ -23,3 +23,24 @@ func detectTerminalWidth() int {
 	}
 	return 0
 }
+
+func detectTerminalHeight() int {
+	fd := int(os.Stdout.Fd())
+	if ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ); err == nil && ws != nil {
+		if ws.Row > 0 {
+			return int(ws.Row)
+		}
+	}
+	if rows, ok := os.LookupEnv("LINES"); ok {
+		if n, err := strconv.Atoi(rows); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// isTerminal reports whether f is attached to a terminal.
+func isTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetWinsize(int(f.Fd()), unix.TIOCGWINSZ)
+	return err == nil
+}
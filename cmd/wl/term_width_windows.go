@@ -5,6 +5,8 @@ package main
 import (
 	"os"
 	"strconv"
+
+	"golang.org/x/sys/windows"
 )
 
 func detectTerminalWidth() int {
@@ -15,3 +17,18 @@ func detectTerminalWidth() int {
 	}
 	return 0
 }
+
+func detectTerminalHeight() int {
+	if rows, ok := os.LookupEnv("LINES"); ok {
+		if n, err := strconv.Atoi(rows); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// isTerminal reports whether f is attached to a console.
+func isTerminal(f *os.File) bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(f.Fd()), &mode) == nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/source"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// newValidateCmd builds `wl validate [path]`, which loads watchlist YAML and
+// the config exactly like the root command and reports problems without
+// fetching anything: unknown columns or column sets, empty lists, and
+// duplicate list names or symbols.
+func newValidateCmd(configPath, configDir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Check watchlist YAML and config columns without fetching quotes",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			wlHome := resolveHome()
+			vp, err := loadViper(resolveConfigPath(*configPath, *configDir, wlHome))
+			if err != nil {
+				return err
+			}
+			var cfg struct {
+				Columns          []string            `mapstructure:"columns"`
+				ColSet           []string            `mapstructure:"col_set"`
+				ColumnSets       map[string][]string `mapstructure:"col_sets"`
+				Computed         map[string]string   `mapstructure:"computed"`
+				Aliases          map[string]string   `mapstructure:"aliases"`
+				DefaultWatchlist string              `mapstructure:"default_watchlist"`
+			}
+			if err := vp.Unmarshal(&cfg); err != nil {
+				return configErrorf("parse config: %w", err)
+			}
+			for k, v := range cfg.ColumnSets {
+				if v != nil {
+					columns.Sets[k] = append([]string(nil), v...)
+				}
+			}
+			if err := registerConfigColumns(cfg.Computed, cfg.Aliases); err != nil {
+				return err
+			}
+			var spec string
+			if len(args) == 1 {
+				spec = args[0]
+			} else {
+				spec = defaultWatchlistPath(cfg.DefaultWatchlist, wlHome)
+			}
+			if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+				return usageErrorf("validate checks local files only, not %s", spec)
+			}
+			lists, err := source.Load(cmd.Context(), source.YAMLSource{KeepEmpty: true}, spec)
+			if err != nil {
+				return err
+			}
+			problems := validateLists(lists, cfg.Columns, cfg.ColSet, cfg.ColumnSets)
+			return reportValidation(cmd.OutOrStdout(), lists, problems)
+		},
+	}
+}
+
+// validateLists returns one message per problem found in lists and the
+// config's columns, col_set and col_sets. A column is known when it is a
+// registered column (or alias), a "module.*" or "-col" token over one, the
+// "yaml" token, or a YAML field of an item it applies to.
+func validateLists(lists []types.Watchlist, cfgColumns, cfgColSet []string, cfgSets map[string][]string) []string {
+	var problems []string
+	allFields := map[string]bool{}
+	seenNames := map[string]bool{}
+	for _, l := range lists {
+		label := fmt.Sprintf("list %q", l.Name)
+		if seenNames[l.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate list name %q", l.Name))
+		}
+		seenNames[l.Name] = true
+		if len(l.Items) == 0 {
+			problems = append(problems, label+": no items")
+		}
+		fields := map[string]bool{}
+		seenSyms := map[string]bool{}
+		for _, it := range l.Items {
+			sym := strings.ToUpper(strings.TrimSpace(it.Sym))
+			if sym == "" {
+				problems = append(problems, label+": item without a symbol")
+			} else if seenSyms[sym] {
+				problems = append(problems, fmt.Sprintf("%s: duplicate symbol %s", label, it.Sym))
+			}
+			seenSyms[sym] = true
+			for k := range it.Fields {
+				fields[strings.ToLower(k)] = true
+				allFields[strings.ToLower(k)] = true
+			}
+		}
+		problems = append(problems, unknownColumns(label, l.Columns, fields)...)
+		if _, err := columns.ExpandSets(l.ColSet); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: col_set: %s", label, unknownSetName(err)))
+		}
+	}
+	problems = append(problems, unknownColumns("config columns", cfgColumns, allFields)...)
+	if _, err := columns.ExpandSets(cfgColSet); err != nil {
+		problems = append(problems, "config col_set: "+unknownSetName(err))
+	}
+	setNames := make([]string, 0, len(cfgSets))
+	for k := range cfgSets {
+		setNames = append(setNames, k)
+	}
+	sort.Strings(setNames)
+	for _, k := range setNames {
+		problems = append(problems, unknownColumns("config col_sets."+k, cfgSets[k], allFields)...)
+	}
+	return problems
+}
+
+// unknownColumns reports the tokens of cols that name no column.
+func unknownColumns(label string, cols []string, fields map[string]bool) []string {
+	modules := columns.AvailableByModule()
+	var out []string
+	for _, tok := range cols {
+		t := strings.TrimPrefix(strings.TrimSpace(tok), "-")
+		if t == "" || strings.EqualFold(t, "yaml") {
+			continue
+		}
+		if mod, ok := strings.CutSuffix(t, ".*"); ok {
+			found := false
+			for name := range modules {
+				found = found || strings.EqualFold(name, mod)
+			}
+			if !found {
+				out = append(out, fmt.Sprintf("%s: unknown module %q", label, t))
+			}
+			continue
+		}
+		if k, ok := columns.Canonical(t); ok || fields[k] || k == "sym" || k == "name" {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s: unknown column %q", label, t))
+	}
+	return out
+}
+
+// unknownSetName turns an ExpandSets error into a short message.
+func unknownSetName(err error) string {
+	var use *columns.UnknownSetError
+	if errors.As(err, &use) {
+		return fmt.Sprintf("unknown column set %q", use.Name)
+	}
+	return err.Error()
+}
+
+// reportValidation prints problems (or a summary when there are none) and
+// returns an error when anything was found.
+func reportValidation(w io.Writer, lists []types.Watchlist, problems []string) error {
+	if len(problems) == 0 {
+		syms := 0
+		for _, l := range lists {
+			syms += len(l.Items)
+		}
+		fmt.Fprintf(w, "ok: %d list(s), %d symbol(s)\n", len(lists), syms)
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Fprintln(w, p)
+	}
+	return fmt.Errorf("%d problem(s) found", len(problems))
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+)
+
+// viewColumn is one entry of a view: the column, an optional header label
+// and an optional fixed width for table output.
+type viewColumn struct {
+	Col   string `yaml:"col" mapstructure:"col"`
+	Label string `yaml:"label" mapstructure:"label"`
+	Width int    `yaml:"width" mapstructure:"width"`
+}
+
+// view is an ordered column layout, loaded from a --view file or from the
+// config's views section, optionally with sort and heatmap defaults and
+// color rules (same shape as config color_rules).
+type view struct {
+	Columns    []viewColumn                 `yaml:"columns" mapstructure:"columns"`
+	Sort       string                       `yaml:"sort" mapstructure:"sort"`
+	Desc       bool                         `yaml:"desc" mapstructure:"desc"`
+	Heatmap    []string                     `yaml:"heatmap" mapstructure:"heatmap"`
+	ColorRules map[string][]colorRuleConfig `yaml:"color_rules" mapstructure:"color_rules"`
+}
+
+// resolveView returns the config view called name (case-insensitive, as
+// config keys are), else loads name as a view file. Failures are config
+// errors.
+func resolveView(name string, views map[string]view) (view, error) {
+	v, err := findView(name, views)
+	if err != nil {
+		return view{}, &configError{err: err}
+	}
+	return v, nil
+}
+
+func findView(name string, views map[string]view) (view, error) {
+	for k, v := range views {
+		if strings.EqualFold(k, name) {
+			if err := v.validate(); err != nil {
+				return view{}, fmt.Errorf("view %s: %w", k, err)
+			}
+			return v, nil
+		}
+	}
+	v, err := loadViewFile(resolvePath(name, ""))
+	if errors.Is(err, os.ErrNotExist) && !strings.ContainsRune(name, filepath.Separator) && filepath.Ext(name) == "" {
+		names := make([]string, 0, len(views))
+		for k := range views {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		return view{}, fmt.Errorf("unknown view %q (config views: %s)", name, strings.Join(names, ", "))
+	}
+	return v, err
+}
+
+// loadViewFile reads a view from YAML: either a bare list of columns or a
+// map with a "columns" key and optional sort, desc, heatmap and color_rules.
+func loadViewFile(path string) (view, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return view{}, err
+	}
+	var v view
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return view{}, fmt.Errorf("view %s: %w", path, err)
+	}
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+		err = node.Content[0].Decode(&v.Columns)
+	} else {
+		err = node.Decode(&v)
+	}
+	if err != nil {
+		return view{}, fmt.Errorf("view %s: %w", path, err)
+	}
+	if err := v.validate(); err != nil {
+		return view{}, fmt.Errorf("view %s: %w", path, err)
+	}
+	return v, nil
+}
+
+func (v view) validate() error {
+	if len(v.Columns) == 0 {
+		return fmt.Errorf("no columns defined")
+	}
+	for i, c := range v.Columns {
+		if strings.TrimSpace(c.Col) == "" {
+			return fmt.Errorf("column %d: missing col", i+1)
+		}
+		if c.Width < 0 {
+			return fmt.Errorf("column %s: width must be positive", c.Col)
+		}
+	}
+	return nil
+}
+
+// cols returns the view's columns in order.
+func (v view) cols() []string {
+	out := make([]string, 0, len(v.Columns))
+	for _, c := range v.Columns {
+		out = append(out, strings.TrimSpace(c.Col))
+	}
+	return out
+}
+
+// labels maps canonical column keys to their header labels.
+func (v view) labels() map[string]string {
+	out := map[string]string{}
+	for _, c := range v.Columns {
+		if strings.TrimSpace(c.Label) != "" {
+			out[canonicalKey(c.Col)] = c.Label
+		}
+	}
+	return out
+}
+
+// widths maps canonical column keys to their fixed widths.
+func (v view) widths() map[string]int {
+	out := map[string]int{}
+	for _, c := range v.Columns {
+		if c.Width > 0 {
+			out[canonicalKey(c.Col)] = c.Width
+		}
+	}
+	return out
+}
+
+func canonicalKey(col string) string {
+	col = strings.TrimSpace(col)
+	if k, ok := columns.Canonical(col); ok {
+		return k
+	}
+	return col
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadViewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "movers.yaml")
+	data := `columns:
+  - {col: sym, width: 8}
+  - {col: chg%, label: Chg}
+sort: chg%
+desc: true
+heatmap: [chg%]
+color_rules:
+  chg%: [{op: ">", value: 5, color: green}]
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v, err := loadViewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := v.cols(); !reflect.DeepEqual(got, []string{"sym", "chg%"}) {
+		t.Errorf("cols = %v", got)
+	}
+	if v.labels()["chg%"] != "Chg" || v.widths()["sym"] != 8 || v.Sort != "chg%" || !v.Desc {
+		t.Errorf("view = %+v", v)
+	}
+	rules, err := parseColorRules(v.ColorRules, "view movers color_rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].Column != "chg%" {
+		t.Errorf("color rules = %+v", rules)
+	}
+}
+
+func TestResolveView(t *testing.T) {
+	views := map[string]view{"movers": {Columns: []viewColumn{{Col: "sym"}, {Col: "chg%"}}}}
+	v, err := resolveView("Movers", views)
+	if err != nil || len(v.Columns) != 2 {
+		t.Fatalf("resolveView(Movers) = %+v, %v", v, err)
+	}
+	for _, name := range []string{"nope", "empty", filepath.Join(t.TempDir(), "missing.yaml")} {
+		_, err := resolveView(name, map[string]view{"movers": views["movers"], "empty": {}})
+		if code := exitCode(err); code != exitConfig {
+			t.Errorf("resolveView(%q): exit code %d (%v), want %d", name, code, err, exitConfig)
+		}
+	}
+}
+
+func TestViewFileAppliesLabelsAndWidths(t *testing.T) {
+	home := t.TempDir()
+	writeFile(t, home, "us.yaml", "watchlist:\n  - sym: AAPL\n")
+	writeFile(t, home, "view.yaml", "- {col: sym, width: 8}\n- {col: price, label: Last}\n")
+	cacheQuote(t, home+"/cache", "AAPL", "price", priceModule(180, 1.25))
+	r := runWL(t, home, nil, "--offline", "--cache-dir", "cache", "--view", "view.yaml", "us.yaml")
+	if r.code != exitOK {
+		t.Fatalf("exit %d: %s", r.code, r.stderr)
+	}
+	lines := strings.Split(r.stdout, "\n")
+	if len(lines) < 2 {
+		t.Fatalf("output:\n%s", r.stdout)
+	}
+	if got := strings.Fields(lines[0]); !reflect.DeepEqual(got, []string{"SYM", "LAST"}) {
+		t.Errorf("header = %v, want [SYM LAST]", got)
+	}
+	// the sym column is 8 wide, padded past its 4-letter values
+	if cell := lines[1][1:9]; cell != "AAPL    " {
+		t.Errorf("sym cell = %q, want it padded to 8", cell)
+	}
+	if !strings.Contains(lines[1], "180.00") {
+		t.Errorf("row = %q", lines[1])
+	}
+}
+
+func TestConfigViewAppliesAllSettings(t *testing.T) {
+	home := t.TempDir()
+	writeFile(t, home, "config.yaml", `views:
+  movers:
+    columns:
+      - {col: sym, width: 8}
+      - {col: price, label: Last}
+      - {col: chg%}
+    sort: chg%
+    desc: true
+    heatmap: [chg%]
+    color_rules:
+      price: [{op: ">", value: 300, color: magenta}]
+`)
+	writeFile(t, home, "us.yaml", "watchlist:\n  - sym: AAPL\n  - sym: MSFT\n  - sym: F\n")
+	cacheQuote(t, home+"/cache", "AAPL", "price", priceModule(180, 1.25))
+	cacheQuote(t, home+"/cache", "MSFT", "price", priceModule(400, -0.5))
+	cacheQuote(t, home+"/cache", "F", "price", priceModule(9.5, 3))
+	base := []string{"--offline", "--cache-dir", "cache", "--view", "Movers", "us.yaml"}
+
+	r := runWL(t, home, nil, base...)
+	if r.code != exitOK {
+		t.Fatalf("exit %d: %s", r.code, r.stderr)
+	}
+	lines := strings.Split(strings.TrimRight(r.stdout, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("output:\n%s", r.stdout)
+	}
+	// columns and label
+	if got := strings.Fields(lines[0]); !reflect.DeepEqual(got, []string{"SYM", "LAST", "CHG%"}) {
+		t.Errorf("header = %v, want [SYM LAST CHG%%]", got)
+	}
+	// sort: chg% descending
+	var order []string
+	for _, l := range lines[1:] {
+		order = append(order, strings.Fields(l)[0])
+	}
+	if !reflect.DeepEqual(order, []string{"F", "AAPL", "MSFT"}) {
+		t.Errorf("row order = %v, want [F AAPL MSFT]", order)
+	}
+	// width: sym padded to 8
+	if cell := lines[1][1:9]; cell != "F       " {
+		t.Errorf("sym cell = %q, want it padded to 8", cell)
+	}
+
+	r = runWL(t, home, nil, append(base, "--color")...)
+	if r.code != exitOK {
+		t.Fatalf("--color: exit %d: %s", r.code, r.stderr)
+	}
+	rows := strings.Split(r.stdout, "\n")
+	// color rule: MSFT's price is magenta instead of red for its fall
+	if !strings.Contains(rows[3], "\x1b[35m400.00") {
+		t.Errorf("MSFT row lacks the magenta color rule: %q", rows[3])
+	}
+	// heatmap: chg% cells get a gradient color rather than the sign's
+	for i, cell := range []string{"3.00%", "1.25%", "-0.50%"} {
+		if !strings.Contains(rows[i+1], "\x1b[38;2;") || !strings.Contains(rows[i+1], "m"+cell) {
+			t.Errorf("row %d lacks a heatmap color on %s: %q", i+1, cell, rows[i+1])
+		}
+	}
+}
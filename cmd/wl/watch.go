@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/pipeline"
+	"github.com/komsit37/wl/pkg/wl/source"
+)
+
+// watchLoop renders once, then again every interval until ctx is done.
+// When gate is non-nil it is consulted before each refresh and may skip it.
+// Render errors are reported to stderr and the loop keeps going.
+func watchLoop(ctx context.Context, interval time.Duration, render func(context.Context) error, gate func(context.Context) bool) error {
+	if err := render(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if gate != nil && !gate(ctx) {
+				continue
+			}
+			if err := render(ctx); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+			}
+		}
+	}
+}
+
+// writeWatchHeader clears the terminal and prints what triggers refreshes
+// ("Every 30s", "On change") and the refresh time, like watch(1), ahead of
+// each --watch or --watch-on-change frame.
+func writeWatchHeader(w io.Writer, trigger string, now time.Time) {
+	fmt.Fprintf(w, "\x1b[H\x1b[2J\x1b[3J%s: refreshed %s (Ctrl-C to quit)\n\n", trigger, now.Format("2006-01-02 15:04:05"))
+}
+
+// marketGate returns a watch gate that allows refreshes only during market
+// hours: from the configured schedule when tz is set, otherwise by probing
+// price.marketState for one symbol per exchange.
+func marketGate(tz, open, closeAt string, src source.Source, spec any, newClient func() (*yfgo.Client, error)) (func(context.Context) bool, error) {
+	if strings.TrimSpace(tz) != "" {
+		sched, err := pipeline.ParseMarketSchedule(tz, open, closeAt)
+		if err != nil {
+			return nil, err
+		}
+		return func(context.Context) bool { return sched.IsOpen(time.Now()) }, nil
+	}
+	client, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) bool {
+		lists, err := src.Load(ctx, spec)
+		if err != nil {
+			return true
+		}
+		var states []string
+		probeCtx := yfgo.WithCacheOptions(ctx, yfgo.BypassCache())
+		for _, sym := range pipeline.ProbeSymbols(lists) {
+			raw, err := client.QuoteSummary(probeCtx, sym, []yfgo.QuoteSummaryModule{yfgo.ModulePrice})
+			if err != nil {
+				states = append(states, "")
+				continue
+			}
+			st, _ := columns.Extract(columns.RawToMap(raw), "price.marketState")
+			states = append(states, st)
+		}
+		return pipeline.MarketOpen(states)
+	}, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchLoopGate(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		open    bool
+		atLeast int32
+		atMost  int32
+	}{
+		{"market open refreshes", true, 3, 1 << 30},
+		{"market closed renders only the first frame", false, 1, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Millisecond)
+			defer cancel()
+			var renders, gates atomic.Int32
+			render := func(context.Context) error { renders.Add(1); return nil }
+			gate := func(context.Context) bool { gates.Add(1); return tc.open }
+			if err := watchLoop(ctx, 5*time.Millisecond, render, gate); err != nil {
+				t.Fatal(err)
+			}
+			if n := renders.Load(); n < tc.atLeast || n > tc.atMost {
+				t.Errorf("rendered %d times, want %d..%d", n, tc.atLeast, tc.atMost)
+			}
+			if gates.Load() == 0 {
+				t.Error("gate never consulted")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long watchOnChange waits after the last file event
+// before re-rendering, so an editor's write-rename-chmod burst renders once.
+const watchDebounce = 200 * time.Millisecond
+
+// watchOnChange renders once, then again whenever spec changes on disk,
+// until ctx is done. A file is watched through its directory so editors that
+// save by renaming a temp file over it are seen; a directory (or a glob's
+// literal leading directory) is watched recursively, including directories
+// created later. Render errors, e.g. YAML mid-edit, are reported to stderr
+// and the watch keeps going.
+func watchOnChange(ctx context.Context, spec string, render func(context.Context) error) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	file, err := addWatchPaths(w, spec)
+	if err != nil {
+		return err
+	}
+	if err := render(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if file != "" && filepath.Clean(ev.Name) != file {
+				continue
+			}
+			if ev.Op == fsnotify.Chmod {
+				continue
+			}
+			if file == "" && ev.Has(fsnotify.Create) {
+				if st, err := os.Stat(ev.Name); err == nil && st.IsDir() {
+					_ = addWatchTree(w, ev.Name)
+				}
+			}
+			timer.Reset(watchDebounce)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintln(os.Stderr, "watch:", err)
+		case <-timer.C:
+			if err := render(ctx); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+			}
+		}
+	}
+}
+
+// addWatchPaths registers what to watch for spec. For a single file it
+// watches the parent directory and returns the cleaned file path to filter
+// events on; otherwise it returns "".
+func addWatchPaths(w *fsnotify.Watcher, spec string) (string, error) {
+	if strings.ContainsAny(spec, "*?[") {
+		return "", addWatchTree(w, globRoot(spec))
+	}
+	st, err := os.Stat(spec)
+	if err != nil {
+		return "", err
+	}
+	if st.IsDir() {
+		return "", addWatchTree(w, spec)
+	}
+	file := filepath.Clean(spec)
+	return file, w.Add(filepath.Dir(file))
+}
+
+// addWatchTree watches root and every directory below it.
+func addWatchTree(w *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return w.Add(p)
+		}
+		return nil
+	})
+}
+
+// globRoot returns the literal directory a glob pattern starts from, e.g.
+// "us" for "us/**/*.yaml", matching how the YAML source names glob lists.
+func globRoot(pattern string) string {
+	segs := strings.Split(filepath.ToSlash(pattern), "/")
+	n := 0
+	for n < len(segs) && !strings.ContainsAny(segs[n], "*?[") {
+		n++
+	}
+	root := strings.Join(segs[:n], "/")
+	if root == "" {
+		if strings.HasPrefix(pattern, "/") {
+			return "/"
+		}
+		return "."
+	}
+	return filepath.FromSlash(root)
+}
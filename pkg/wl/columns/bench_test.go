@@ -0,0 +1,56 @@
+package columns
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// benchRaws returns n quoteSummary-shaped raw maps.
+func benchRaws(n int) ([]types.Item, []map[string]any) {
+	items := make([]types.Item, n)
+	raws := make([]map[string]any, n)
+	for i := range raws {
+		price := 10 + float64(i%997)
+		items[i] = types.Item{Sym: fmt.Sprintf("S%04d", i), Fields: map[string]any{"cost": price * 0.9, "shares": 100}}
+		raws[i] = map[string]any{
+			"price": map[string]any{
+				"shortName":          fmt.Sprintf("Company %d", i),
+				"regularMarketPrice": map[string]any{"raw": price, "fmt": fmt.Sprintf("%.2f", price)},
+				"marketCap":          map[string]any{"raw": price * 1e9, "fmt": fmt.Sprintf("%.2fB", price)},
+			},
+			"summaryDetail": map[string]any{
+				"fiftyTwoWeekHigh": map[string]any{"raw": price * 1.2, "fmt": fmt.Sprintf("%.2f", price*1.2)},
+				"fiftyTwoWeekLow":  map[string]any{"raw": price * 0.7, "fmt": fmt.Sprintf("%.2f", price*0.7)},
+			},
+		}
+	}
+	return items, raws
+}
+
+func BenchmarkExtract(b *testing.B) {
+	_, raws := benchRaws(1000)
+	paths := []string{"price.regularMarketPrice.fmt", "price.marketCap.raw", "price.shortName|price.longName", "summaryDetail.trailingPE.fmt"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, m := range raws {
+			for _, p := range paths {
+				Extract(m, p)
+			}
+		}
+	}
+}
+
+func BenchmarkNumericValue(b *testing.B) {
+	items, raws := benchRaws(1000)
+	cols := []string{"price", "mktcap", "off_high%", "range52", "pnl"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for r, m := range raws {
+			for _, c := range cols {
+				NumericValue(c, items[r], m)
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	yfgo "github.com/komsit37/yf-go"
 
@@ -18,14 +19,42 @@ type ColumnDef struct {
 	Key     string
 	Aliases []string
 	Module  yfgo.QuoteSummaryModule
-	Path    string // dot path with '|' fallbacks, terminal len() for arrays
+	Path    string // dot path with '|' fallbacks; see Extract for array segments
+	// Currency marks amounts in the quote's currency (prices, market cap),
+	// which renderers convert when RenderOptions.ConvertTo is set.
+	Currency bool
+	// Requires lists further modules a derived column reads besides Module.
+	Requires []yfgo.QuoteSummaryModule
+	// Value is a derived column's number, unrounded, for sorting and
+	// computed expressions; its Render formats it (see derived.go).
+	Value func(ctx CellContext) (float64, bool)
 
 	// Styling/formatting hooks
 	Align  Align                           // explicit align; if AlignAuto, renderer may apply heuristics
 	Render func(ctx CellContext) string    // custom renderer; if nil, use Path/YAML fallback
 	Style  func(ctx CellContext) CellStyle // dynamic per-cell style; if nil, no styling
+
+	expr *Expr // set for computed columns (see RegisterComputed)
+}
+
+// Modules returns Module followed by Requires, skipping an empty Module.
+func (def ColumnDef) Modules() []yfgo.QuoteSummaryModule {
+	var mods []yfgo.QuoteSummaryModule
+	if def.Module != "" {
+		mods = append(mods, def.Module)
+	}
+	return append(mods, def.Requires...)
 }
 
+// ModuleChart is a synthetic module for columns backed by chart history
+// rather than quoteSummary. The table renderer fetches it separately and
+// stores the closes under Raw["chart"]["closes"].
+const ModuleChart yfgo.QuoteSummaryModule = "chart"
+
+// RowNumberKey is the synthetic column numbering rows 1..N within each list,
+// after sorting. Renderers fill it in; it is never fetched.
+const RowNumberKey = "#"
+
 var (
 	defsByKey  = map[string]ColumnDef{}
 	aliasToKey = map[string]string{}
@@ -85,6 +114,25 @@ func RegisterDef(def ColumnDef) {
 	}
 }
 
+// AddAlias registers alias as another name for the column target (itself
+// a key or alias). It fails when target is unknown or alias already names a
+// different column.
+func AddAlias(alias, target string) error {
+	a := strings.ToLower(strings.TrimSpace(alias))
+	if a == "" {
+		return fmt.Errorf("empty alias for %q", target)
+	}
+	k, ok := Canonical(target)
+	if _, known := defsByKey[k]; !ok || !known {
+		return fmt.Errorf("alias %s: unknown column %q", a, target)
+	}
+	if cur, ok := aliasToKey[a]; ok && cur != k {
+		return fmt.Errorf("alias %s: already names column %s", a, cur)
+	}
+	aliasToKey[a] = k
+	return nil
+}
+
 // Canonical resolves a provided name to its canonical key.
 func Canonical(col string) (string, bool) {
 	lc := strings.ToLower(strings.TrimSpace(col))
@@ -113,19 +161,78 @@ func AvailableByModule() map[string][]string {
 	return groups
 }
 
+// AllKeys returns every registered canonical column key, sorted.
+func AllKeys() []string {
+	keys := make([]string, 0, len(defsByKey))
+	for k := range defsByKey {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// CurrencyPaths returns every '|' alternative of the Currency columns'
+// paths, sorted and without duplicates.
+func CurrencyPaths() []string {
+	seen := map[string]bool{}
+	var paths []string
+	for _, def := range defsByKey {
+		if !def.Currency {
+			continue
+		}
+		for _, p := range strings.Split(def.Path, "|") {
+			if p = strings.TrimSpace(p); p != "" && !seen[p] {
+				seen[p] = true
+				paths = append(paths, p)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 // registerMeta defines all built-in columns in one place.
 func registerMeta() {
 	// Base
 	RegisterDef(ColumnDef{Key: "sym", Align: AlignLeft})
+	RegisterDef(ColumnDef{Key: RowNumberKey, Aliases: []string{"row"}, Align: AlignRight})
 	RegisterDef(ColumnDef{Key: "name", Module: yfgo.ModulePrice, Path: "price.shortName|price.longName", Align: AlignLeft})
 
 	// Price
-	RegisterDef(ColumnDef{Key: "price", Module: yfgo.ModulePrice, Path: "price.regularMarketPrice.fmt",
+	RegisterDef(ColumnDef{Key: "price", Module: yfgo.ModulePrice, Path: "price.regularMarketPrice.fmt", Currency: true,
 		Style: ColorBySign("price.regularMarketChangePercent.raw"),
 	})
 	RegisterDef(ColumnDef{Key: "chg%", Module: yfgo.ModulePrice, Path: "price.regularMarketChangePercent.fmt",
 		Style: ColorBySign("price.regularMarketChangePercent.raw"),
 	})
+	RegisterDef(ColumnDef{Key: "pre_price", Module: yfgo.ModulePrice, Path: "price.preMarketPrice.fmt", Currency: true,
+		Style: ColorBySign("price.preMarketChangePercent.raw"),
+	})
+	RegisterDef(ColumnDef{Key: "pre_chg%", Module: yfgo.ModulePrice, Path: "price.preMarketChangePercent.fmt",
+		Style: ColorBySign("price.preMarketChangePercent.raw"),
+	})
+	RegisterDef(ColumnDef{Key: "post_price", Module: yfgo.ModulePrice, Path: "price.postMarketPrice.fmt", Currency: true,
+		Style: ColorBySign("price.postMarketChangePercent.raw"),
+	})
+	RegisterDef(ColumnDef{Key: "post_chg%", Module: yfgo.ModulePrice, Path: "price.postMarketChangePercent.fmt",
+		Style: ColorBySign("price.postMarketChangePercent.raw"),
+	})
+	RegisterDef(ColumnDef{Key: "state", Aliases: []string{"market_state"}, Module: yfgo.ModulePrice, Path: "price.marketState", Align: AlignLeft,
+		Style: styleMarketState,
+	})
+	RegisterDef(ColumnDef{Key: "as_of", Module: yfgo.ModulePrice, Align: AlignRight, Render: renderAsOf}) // derived
+	// derived from the item's cost and shares fields (holdings); outside
+	// the price set since most items have no position
+	RegisterDef(ColumnDef{Key: "mkt_value", Requires: []yfgo.QuoteSummaryModule{yfgo.ModulePrice}, Align: AlignRight, Value: mktValueValue, Render: amountRender(mktValueValue)})
+	RegisterDef(ColumnDef{Key: "pnl", Requires: []yfgo.QuoteSummaryModule{yfgo.ModulePrice}, Align: AlignRight, Value: pnlValue, Render: amountRender(pnlValue),
+		Style: ColorBySign(""),
+	})
+	RegisterDef(ColumnDef{Key: "pnl%", Requires: []yfgo.QuoteSummaryModule{yfgo.ModulePrice}, Align: AlignRight, Value: pnlPercentValue, Render: percentRender(pnlPercentValue),
+		Style: ColorBySign(""),
+	})
+	// share of the list's total mkt_value; the table renderer stores it
+	// under WeightPath first, other outputs leave it blank
+	RegisterDef(ColumnDef{Key: "weight%", Requires: []yfgo.QuoteSummaryModule{yfgo.ModulePrice}, Align: AlignRight, Value: weightValue, Render: percentRender(weightValue)})
 
 	// AssetProfile
 	RegisterDef(ColumnDef{Key: "sector", Module: yfgo.ModuleAssetProfile, Path: "assetProfile.sector"})
@@ -159,24 +266,29 @@ func registerMeta() {
 	RegisterDef(ColumnDef{Key: "earn_g%", Module: yfgo.ModuleFinancialData, Path: "financialData.earningsGrowth.fmt",
 		Style: ColorBySign("financialData.earningsGrowth.raw"),
 	})
-	RegisterDef(ColumnDef{Key: "rev_ps", Module: yfgo.ModuleFinancialData, Path: "financialData.revenuePerShare.fmt"})
-	RegisterDef(ColumnDef{Key: "cash", Module: yfgo.ModuleFinancialData, Path: "financialData.totalCash.fmt"})
-	RegisterDef(ColumnDef{Key: "debt", Module: yfgo.ModuleFinancialData, Path: "financialData.totalDebt.fmt"})
-	RegisterDef(ColumnDef{Key: "fcf", Module: yfgo.ModuleFinancialData, Path: "financialData.freeCashflow.fmt",
+	RegisterDef(ColumnDef{Key: "rev_ps", Module: yfgo.ModuleFinancialData, Path: "financialData.revenuePerShare.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "cash", Module: yfgo.ModuleFinancialData, Path: "financialData.totalCash.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "debt", Module: yfgo.ModuleFinancialData, Path: "financialData.totalDebt.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "fcf", Module: yfgo.ModuleFinancialData, Path: "financialData.freeCashflow.fmt", Currency: true,
 		Style: ColorBySign("financialData.freeCashflow.raw"),
 	})
-	RegisterDef(ColumnDef{Key: "ocf", Module: yfgo.ModuleFinancialData, Path: "financialData.operatingCashflow.fmt",
+	RegisterDef(ColumnDef{Key: "ocf", Module: yfgo.ModuleFinancialData, Path: "financialData.operatingCashflow.fmt", Currency: true,
 		Style: ColorBySign("financialData.operatingCashflow.raw"),
 	})
-	RegisterDef(ColumnDef{Key: "tgt_mean", Module: yfgo.ModuleFinancialData, Path: "financialData.targetMeanPrice.fmt"})
+	RegisterDef(ColumnDef{Key: "tgt_mean", Module: yfgo.ModuleFinancialData, Path: "financialData.targetMeanPrice.fmt", Currency: true})
 	RegisterDef(ColumnDef{Key: "reco", Module: yfgo.ModuleFinancialData, Path: "financialData.recommendationKey"})
+	// derived, see derived.go
+	RegisterDef(ColumnDef{Key: "tgt_spread%", Module: yfgo.ModuleFinancialData, Align: AlignRight, Value: tgtSpreadValue, Render: percentRender(tgtSpreadValue)})
+	RegisterDef(ColumnDef{Key: "tgt_upside%", Module: yfgo.ModuleFinancialData, Requires: []yfgo.QuoteSummaryModule{yfgo.ModulePrice}, Align: AlignRight, Value: tgtUpsideValue, Render: percentRender(tgtUpsideValue),
+		Style: ColorBySign(""),
+	})
 	RegisterDef(ColumnDef{Key: "analysts", Module: yfgo.ModuleFinancialData, Path: "financialData.numberOfAnalystOpinions.raw"})
 
 	// SummaryDetail
-	RegisterDef(ColumnDef{Key: "mktcap", Aliases: []string{"marketcap", "MarketCap", "market_cap"}, Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.marketCap.fmt|price.marketCap.fmt"})
+	RegisterDef(ColumnDef{Key: "mktcap", Aliases: []string{"marketcap", "MarketCap", "market_cap"}, Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.marketCap.fmt|price.marketCap.fmt", Currency: true})
 	RegisterDef(ColumnDef{Key: "beta", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.beta.fmt"})
 	RegisterDef(ColumnDef{Key: "div_yield%", Aliases: []string{"div%"}, Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.dividendYield.fmt"})
-	RegisterDef(ColumnDef{Key: "div_rate", Aliases: []string{"div"}, Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.dividendRate.fmt"})
+	RegisterDef(ColumnDef{Key: "div_rate", Aliases: []string{"div"}, Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.dividendRate.fmt", Currency: true})
 	RegisterDef(ColumnDef{Key: "payout%", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.payoutRatio.fmt"})
 	RegisterDef(ColumnDef{Key: "pe_ttm", Aliases: []string{"pe"}, Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.trailingPE.fmt"})
 	RegisterDef(ColumnDef{Key: "pe_fwd", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.forwardPE.fmt"})
@@ -184,19 +296,35 @@ func registerMeta() {
 	RegisterDef(ColumnDef{Key: "avg_vol", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.averageVolume.fmt|summaryDetail.volume.fmt"})
 	RegisterDef(ColumnDef{Key: "avg_vol10d", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.averageDailyVolume10Day.fmt|summaryDetail.averageVolume10days.fmt"})
 	RegisterDef(ColumnDef{Key: "vol", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.regularMarketVolume.fmt|summaryDetail.volume.fmt"})
-	RegisterDef(ColumnDef{Key: "open", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.open.fmt"})
-	RegisterDef(ColumnDef{Key: "prev_close", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.previousClose.fmt"})
-	RegisterDef(ColumnDef{Key: "50d_avg", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.fiftyDayAverage.fmt"})
-	RegisterDef(ColumnDef{Key: "200d_avg", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.twoHundredDayAverage.fmt"})
-	RegisterDef(ColumnDef{Key: "day_high", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.dayHigh.fmt"})
-	RegisterDef(ColumnDef{Key: "day_low", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.dayLow.fmt"})
-	RegisterDef(ColumnDef{Key: "52w_high", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.fiftyTwoWeekHigh.fmt"})
-	RegisterDef(ColumnDef{Key: "52w_low", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.fiftyTwoWeekLow.fmt"})
-	RegisterDef(ColumnDef{Key: "ath", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.allTimeHigh.fmt"})
-	RegisterDef(ColumnDef{Key: "atl", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.allTimeLow.fmt"})
+	RegisterDef(ColumnDef{Key: "open", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.open.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "prev_close", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.previousClose.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "50d_avg", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.fiftyDayAverage.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "200d_avg", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.twoHundredDayAverage.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "day_high", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.dayHigh.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "day_low", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.dayLow.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "52w_high", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.fiftyTwoWeekHigh.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "52w_low", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.fiftyTwoWeekLow.fmt", Currency: true})
+	// derived, see derived.go
+	RegisterDef(ColumnDef{Key: "range52", Aliases: []string{"pct_of_52w_range"}, Module: yfgo.ModuleSummaryDetail, Requires: []yfgo.QuoteSummaryModule{yfgo.ModulePrice}, Align: AlignRight, Value: range52Value, Render: percentRender(range52Value)})
+	RegisterDef(ColumnDef{Key: "off_high%", Module: yfgo.ModuleSummaryDetail, Requires: []yfgo.QuoteSummaryModule{yfgo.ModulePrice}, Align: AlignRight, Value: offHighValue, Render: percentRender(offHighValue)})
+	RegisterDef(ColumnDef{Key: "ath", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.allTimeHigh.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "atl", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.allTimeLow.fmt", Currency: true})
 	RegisterDef(ColumnDef{Key: "ex_div", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.exDividendDate.fmt"})
 	RegisterDef(ColumnDef{Key: "5y_avg_div_yield", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.fiveYearAvgDividendYield.fmt"})
 	RegisterDef(ColumnDef{Key: "ccy", Module: yfgo.ModuleSummaryDetail, Path: "summaryDetail.currency.fmt"})
+
+	// DefaultKeyStatistics
+	RegisterDef(ColumnDef{Key: "eps", Module: yfgo.ModuleDefaultKeyStatistics, Path: "defaultKeyStatistics.trailingEps.fmt", Currency: true})
+	RegisterDef(ColumnDef{Key: "shares", Aliases: []string{"shares_out"}, Module: yfgo.ModuleDefaultKeyStatistics, Path: "defaultKeyStatistics.sharesOutstanding.fmt"})
+
+	// CalendarEvents (epoch dates formatted with DateFormat)
+	RegisterDef(ColumnDef{Key: "earnings_date", Module: yfgo.ModuleCalendarEvents, Path: "calendarEvents.earnings.earningsDate.0.raw", Align: AlignLeft, Render: renderDate})
+	RegisterDef(ColumnDef{Key: "div_date", Module: yfgo.ModuleCalendarEvents, Path: "calendarEvents.dividendDate.raw", Align: AlignLeft, Render: renderDate})
+
+	// Chart (synthetic; fetched from chart history)
+	RegisterDef(ColumnDef{Key: "spark", Aliases: []string{"sparkline"}, Module: ModuleChart, Align: AlignLeft, Render: renderSpark,
+		Style: colorBySparkTrend,
+	})
 }
 
 func init() {
@@ -215,6 +343,26 @@ func FormatFloat(v float64, decimals int) string {
 	return fmt.Sprintf("%."+strconv.Itoa(decimals)+"f", v)
 }
 
+// PercentDecimals is the precision of percentages wl computes itself
+// (derived columns such as range52); Yahoo's own .fmt strings keep theirs.
+var PercentDecimals = 1
+
+// AsOfFormat is the time layout for the as_of column; empty renders the
+// quote's age instead, e.g. "3m ago".
+var AsOfFormat = ""
+
+// DateFormat is the time layout for date columns such as earnings_date.
+var DateFormat = "2006-01-02"
+
+// AsOfPath locates the quote's epoch seconds in the price module.
+const AsOfPath = "price.regularMarketTime.raw|price.regularMarketTime"
+
+// FormatPercent prints v, already scaled to 0..100, with fixed decimals and
+// a '%' suffix, e.g. "12.3%".
+func FormatPercent(v float64, decimals int) string {
+	return FormatFloat(v, decimals) + "%"
+}
+
 // RawToMap converts yf-go raw to a map for path extraction.
 func RawToMap(v any) map[string]any {
 	b, err := json.Marshal(v)
@@ -254,8 +402,27 @@ func ColorBySign(rawPath string) func(ctx CellContext) CellStyle {
 	}
 }
 
+// ItemName returns the watchlist's own name for an item: Item.Name, else a
+// "name" field matched case-insensitively. It always wins over fetched names.
+func ItemName(it types.Item) string {
+	if n := strings.TrimSpace(it.Name); n != "" {
+		return n
+	}
+	for k, v := range it.Fields {
+		if strings.EqualFold(k, "name") && v != nil {
+			if n := strings.TrimSpace(fmt.Sprint(v)); n != "" {
+				return n
+			}
+		}
+	}
+	return ""
+}
+
 // Extract gets a string for a dot path with fallbacks separated by '|'.
-// Supports terminal len() to get array length.
+// Integer segments index into arrays (e.g. "companyOfficers.0.title"),
+// counting from the end when negative; last() is the same as -1 and may be
+// followed by more segments ("history.last().date"). A terminal len() gives
+// an array's length.
 func Extract(m map[string]any, path string) (string, bool) {
 	if m == nil || strings.TrimSpace(path) == "" {
 		return "", false
@@ -296,12 +463,30 @@ func walkOnce(m map[string]any, path string) (any, bool) {
 			}
 			return nil, false
 		}
-		mm, ok := cur.(map[string]any)
-		if !ok {
-			return nil, false
-		}
-		v, ok := mm[p]
-		if !ok {
+		var v any
+		switch c := cur.(type) {
+		case map[string]any:
+			mv, ok := c[p]
+			if !ok {
+				return nil, false
+			}
+			v = mv
+		case []any:
+			idx, err := strconv.Atoi(p)
+			if p == "last()" {
+				idx, err = -1, nil
+			}
+			if err != nil {
+				return nil, false
+			}
+			if idx < 0 {
+				idx += len(c) // -1 is the last element
+			}
+			if idx < 0 || idx >= len(c) {
+				return nil, false
+			}
+			v = c[idx]
+		default:
 			return nil, false
 		}
 		if i == len(parts)-1 {
@@ -318,13 +503,13 @@ func RequiredModules(cols []string) []yfgo.QuoteSummaryModule {
 	for _, c := range cols {
 		if k, ok := Canonical(c); ok {
 			if def, ok := defsByKey[k]; ok {
-				if def.Module != "" {
-					set[def.Module] = struct{}{}
+				for _, m := range def.Modules() {
+					set[m] = struct{}{}
 				}
 			}
 		}
 	}
-	order := []yfgo.QuoteSummaryModule{yfgo.ModulePrice, yfgo.ModuleAssetProfile, yfgo.ModuleFinancialData, yfgo.ModuleSummaryDetail}
+	order := []yfgo.QuoteSummaryModule{yfgo.ModulePrice, yfgo.ModuleAssetProfile, yfgo.ModuleFinancialData, yfgo.ModuleSummaryDetail, yfgo.ModuleDefaultKeyStatistics, yfgo.ModuleCalendarEvents}
 	out := make([]yfgo.QuoteSummaryModule, 0, len(set))
 	for _, o := range order {
 		if _, ok := set[o]; ok {
@@ -334,6 +519,18 @@ func RequiredModules(cols []string) []yfgo.QuoteSummaryModule {
 	return out
 }
 
+// NeedsModule reports whether any of cols is backed by module.
+func NeedsModule(cols []string, module yfgo.QuoteSummaryModule) bool {
+	for _, c := range cols {
+		if k, ok := Canonical(c); ok {
+			if def, ok := defsByKey[k]; ok && containsModule(def.Modules(), module) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Compute determines final column order from explicit list or inferred from item fields.
 func Compute(explicit []string, items []types.Item) []string {
 	if len(explicit) > 0 {
@@ -589,6 +786,133 @@ func renderCEO(ctx CellContext) string {
 	return base + ageStr
 }
 
+// styleMarketState dims closed markets and highlights pre/post-market
+// sessions.
+func styleMarketState(ctx CellContext) CellStyle {
+	switch s := strings.ToUpper(ctx.Display); {
+	case s == "CLOSED":
+		return CellStyle{Faint: true}
+	case strings.HasPrefix(s, "PRE"), strings.HasPrefix(s, "POST"):
+		return CellStyle{FgColor: ColorYellow}
+	}
+	return CellStyle{}
+}
+
+// renderDate formats the epoch seconds at the column's Path with
+// DateFormat, in UTC since Yahoo's event dates are calendar days.
+func renderDate(ctx CellContext) string {
+	def, ok := GetDef(ctx.Key)
+	if !ok {
+		return ""
+	}
+	sec, ok := rawFloat(ctx.Raw, def.Path)
+	if !ok {
+		return ""
+	}
+	return time.Unix(int64(sec), 0).UTC().Format(DateFormat)
+}
+
+// renderAsOf shows when the quote was last updated, as an age or in
+// AsOfFormat (local time).
+func renderAsOf(ctx CellContext) string {
+	sec, ok := rawFloat(ctx.Raw, AsOfPath)
+	if !ok || sec <= 0 {
+		return ""
+	}
+	t := time.Unix(int64(sec), 0)
+	if AsOfFormat != "" {
+		return t.Local().Format(AsOfFormat)
+	}
+	return FormatAge(time.Since(t))
+}
+
+// FormatAge prints d in its largest whole unit, e.g. "45s ago", "3m ago",
+// "2h ago" or "5d ago".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		if d < 0 {
+			d = 0
+		}
+		return strconv.Itoa(int(d/time.Second)) + "s ago"
+	case d < time.Hour:
+		return strconv.Itoa(int(d/time.Minute)) + "m ago"
+	case d < 24*time.Hour:
+		return strconv.Itoa(int(d/time.Hour)) + "h ago"
+	}
+	return strconv.Itoa(int(d/(24*time.Hour))) + "d ago"
+}
+
+// rawFloat extracts a numeric value at path.
+func rawFloat(m map[string]any, path string) (float64, bool) {
+	v, ok := Extract(m, path)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	return f, err == nil
+}
+
+// chartCloses returns the closes stored under Raw["chart"]["closes"].
+func chartCloses(ctx CellContext) []float64 {
+	val, ok := walkOnce(ctx.Raw, "chart.closes")
+	if !ok {
+		return nil
+	}
+	arr, ok := val.([]any)
+	if !ok {
+		return nil
+	}
+	out := make([]float64, 0, len(arr))
+	for _, e := range arr {
+		if f, ok := e.(float64); ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func renderSpark(ctx CellContext) string {
+	return Sparkline(chartCloses(ctx))
+}
+
+// colorBySparkTrend colors the sparkline by the sign of first-to-last change.
+func colorBySparkTrend(ctx CellContext) CellStyle {
+	closes := chartCloses(ctx)
+	if len(closes) < 2 {
+		return CellStyle{}
+	}
+	chg := closes[len(closes)-1] - closes[0]
+	return ColorBySign("")(CellContext{Numeric: &chg})
+}
+
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as unicode block characters scaled between min and max.
+func Sparkline(vals []float64) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	lo, hi := vals[0], vals[0]
+	for _, v := range vals {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	out := make([]rune, len(vals))
+	for i, v := range vals {
+		idx := len(sparkTicks) / 2
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparkTicks)-1))
+		}
+		out[i] = sparkTicks[idx]
+	}
+	return string(out)
+}
+
 func filterNonEmpty(parts []string) []string {
 	out := make([]string, 0, len(parts))
 	for _, p := range parts {
@@ -0,0 +1,64 @@
+package columns
+
+import (
+	"reflect"
+	"testing"
+
+	yfgo "github.com/komsit37/yf-go"
+)
+
+func TestStateColumn(t *testing.T) {
+	def, ok := GetDef("state")
+	if !ok {
+		t.Fatal("state column not registered")
+	}
+	if def.Path != "price.marketState" {
+		t.Errorf("state path = %q, want price.marketState", def.Path)
+	}
+	if k, ok := Canonical("market_state"); !ok || k != "state" {
+		t.Errorf("market_state alias resolves to %q", k)
+	}
+	if got := RequiredModules([]string{"sym", "state"}); !reflect.DeepEqual(got, []yfgo.QuoteSummaryModule{yfgo.ModulePrice}) {
+		t.Errorf("RequiredModules(sym, state) = %v, want [price]", got)
+	}
+	if !contains(AvailableByModule()[string(yfgo.ModulePrice)], "state") {
+		t.Error("state is not grouped under price")
+	}
+	raw := map[string]any{"price": map[string]any{"marketState": "REGULAR"}}
+	if v, ok := Extract(raw, def.Path); !ok || v != "REGULAR" {
+		t.Errorf("Extract(state) = %q, %v", v, ok)
+	}
+	for display, want := range map[string]CellStyle{
+		"CLOSED":   {Faint: true},
+		"PRE":      {FgColor: ColorYellow},
+		"POSTPOST": {FgColor: ColorYellow},
+		"REGULAR":  {},
+	} {
+		if got := def.Style(CellContext{Key: "state", Display: display}); got != want {
+			t.Errorf("style(%s) = %+v, want %+v", display, got, want)
+		}
+	}
+}
+
+func TestKeyStatisticsColumns(t *testing.T) {
+	want := []yfgo.QuoteSummaryModule{yfgo.ModuleDefaultKeyStatistics}
+	if got := RequiredModules([]string{"sym", "eps"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("RequiredModules(sym, eps) = %v, want %v", got, want)
+	}
+	if got := RequiredModules([]string{"sym", "eps", "shares"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("RequiredModules(sym, eps, shares) = %v, want %v", got, want)
+	}
+	if k, _ := Canonical("shares_out"); k != "shares" {
+		t.Errorf("Canonical(shares_out) = %q, want shares", k)
+	}
+	raw := map[string]any{"defaultKeyStatistics": map[string]any{
+		"trailingEps":       map[string]any{"raw": 6.1, "fmt": "6.10"},
+		"sharesOutstanding": map[string]any{"raw": 1.5e10, "fmt": "15B"},
+	}}
+	for key, want := range map[string]string{"eps": "6.10", "shares": "15B"} {
+		def, _ := GetDef(key)
+		if v, ok := Extract(raw, def.Path); !ok || v != want {
+			t.Errorf("%s = %q, want %q", key, v, want)
+		}
+	}
+}
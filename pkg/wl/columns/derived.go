@@ -0,0 +1,143 @@
+package columns
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Derived columns compute a number from other data. Their ColumnDef.Value
+// returns it unrounded, for sorting and computed expressions; Render is the
+// formatted Value, blank when an input is missing.
+
+// WeightPath is where the table renderer stores a row's weight% (its share
+// of the list's total mkt_value) before rendering, as with chart closes.
+const WeightPath = "portfolio.weight"
+
+// FXRatePath is where --convert-to stores the rate it applied to a quote's
+// amounts, so item fields in the quote's currency (a position's cost)
+// convert alike.
+const FXRatePath = "fx.rate"
+
+// percentRender formats a derived value as a percentage.
+func percentRender(value func(CellContext) (float64, bool)) func(CellContext) string {
+	return func(ctx CellContext) string {
+		if v, ok := value(ctx); ok {
+			return FormatPercent(v, PercentDecimals)
+		}
+		return ""
+	}
+}
+
+// amountRender formats a derived value with two decimals.
+func amountRender(value func(CellContext) (float64, bool)) func(CellContext) string {
+	return func(ctx CellContext) string {
+		if v, ok := value(ctx); ok {
+			return FormatFloat(v, 2)
+		}
+		return ""
+	}
+}
+
+// RawFloats extracts the numbers at raw paths (e.g.
+// "price.regularMarketPrice.raw"), reporting false if any is missing.
+func RawFloats(m map[string]any, paths ...string) ([]float64, bool) {
+	out := make([]float64, len(paths))
+	for i, p := range paths {
+		f, ok := rawFloat(m, p)
+		if !ok {
+			return nil, false
+		}
+		out[i] = f
+	}
+	return out, true
+}
+
+// range52Value returns where the price sits in its 52-week range, 0 at the
+// low and 100 at the high.
+func range52Value(ctx CellContext) (float64, bool) {
+	v, ok := RawFloats(ctx.Raw, "price.regularMarketPrice.raw", "summaryDetail.fiftyTwoWeekLow.raw", "summaryDetail.fiftyTwoWeekHigh.raw")
+	if !ok || v[2] == v[1] {
+		return 0, false
+	}
+	return (v[0] - v[1]) / (v[2] - v[1]) * 100, true
+}
+
+// offHighValue returns how far the price sits below its 52-week high, as a
+// percentage of the high (<= 0 unless the high is stale).
+func offHighValue(ctx CellContext) (float64, bool) {
+	v, ok := RawFloats(ctx.Raw, "price.regularMarketPrice.raw", "summaryDetail.fiftyTwoWeekHigh.raw")
+	if !ok || v[1] == 0 {
+		return 0, false
+	}
+	return (v[0] - v[1]) / v[1] * 100, true
+}
+
+// tgtSpreadValue returns the analysts' high-low target range as a
+// percentage of the mean target.
+func tgtSpreadValue(ctx CellContext) (float64, bool) {
+	v, ok := RawFloats(ctx.Raw, "financialData.targetHighPrice.raw", "financialData.targetLowPrice.raw", "financialData.targetMeanPrice.raw")
+	if !ok || v[2] == 0 {
+		return 0, false
+	}
+	return (v[0] - v[1]) / v[2] * 100, true
+}
+
+// tgtUpsideValue returns how far the mean analyst target is above the
+// price, as a percentage of the price.
+func tgtUpsideValue(ctx CellContext) (float64, bool) {
+	v, ok := RawFloats(ctx.Raw, "financialData.targetMeanPrice.raw", "price.regularMarketPrice.raw")
+	if !ok || v[1] == 0 {
+		return 0, false
+	}
+	return (v[0] - v[1]) / v[1] * 100, true
+}
+
+// itemFloat returns the numeric item field key (case-insensitive).
+func itemFloat(ctx CellContext, key string) (float64, bool) {
+	for k, v := range ctx.Item.Fields {
+		if strings.EqualFold(k, key) && v != nil {
+			return parsePlainNumber(fmt.Sprint(v))
+		}
+	}
+	return 0, false
+}
+
+// holding returns the price, the cost per share and the share count of a
+// position, from the live price and the item's cost and shares fields. The
+// cost is in the quote's currency and converts with the price (see
+// FXRatePath).
+func holding(ctx CellContext) (price, cost, shares float64, ok bool) {
+	price, okPrice := rawFloat(ctx.Raw, "price.regularMarketPrice.raw")
+	cost, okCost := itemFloat(ctx, "cost")
+	shares, okShares := itemFloat(ctx, "shares")
+	if rate, converted := rawFloat(ctx.Raw, FXRatePath); converted {
+		cost *= rate
+	}
+	return price, cost, shares, okPrice && okCost && okShares
+}
+
+// mktValueValue returns price × shares.
+func mktValueValue(ctx CellContext) (float64, bool) {
+	price, _, shares, ok := holding(ctx)
+	return price * shares, ok
+}
+
+// pnlValue returns the unrealized gain, (price - cost) × shares.
+func pnlValue(ctx CellContext) (float64, bool) {
+	price, cost, shares, ok := holding(ctx)
+	return (price - cost) * shares, ok
+}
+
+// pnlPercentValue returns the unrealized gain as a percentage of the cost.
+func pnlPercentValue(ctx CellContext) (float64, bool) {
+	price, cost, _, ok := holding(ctx)
+	if !ok || cost == 0 {
+		return 0, false
+	}
+	return (price - cost) / cost * 100, true
+}
+
+// weightValue returns the weight% stored under WeightPath.
+func weightValue(ctx CellContext) (float64, bool) {
+	return rawFloat(ctx.Raw, WeightPath)
+}
@@ -0,0 +1,107 @@
+package columns
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// Explain writes a trace of how col resolves for a single item: the canonical
+// key, the Yahoo module it needs, each '|' fallback of its path and whether it
+// matched, and the YAML field fallback. It mirrors the lookup order used by
+// the table renderer so a blank cell can be diagnosed.
+func Explain(w io.Writer, col string, it types.Item, m map[string]any) {
+	key, known := Canonical(col)
+	def, hasDef := GetDef(key)
+	sym := it.Sym
+	if sym == "" {
+		sym = "(no sym)"
+	}
+	mod := "-"
+	if hasDef && len(def.Modules()) > 0 {
+		names := make([]string, 0, 1+len(def.Requires))
+		for _, m := range def.Modules() {
+			names = append(names, string(m))
+		}
+		mod = strings.Join(names, "+")
+	}
+	fmt.Fprintf(w, "%s: col=%s key=%s registered=%t module=%s\n", sym, col, key, known && hasDef, mod)
+	if m == nil && hasDef && len(def.Modules()) > 0 {
+		fmt.Fprintf(w, "  fetch: no data returned for module %s\n", mod)
+	}
+
+	switch key {
+	case RowNumberKey:
+		fmt.Fprintf(w, "  row number: filled in by the renderer after sorting\n")
+		return
+	case "sym":
+		fmt.Fprintf(w, "  item sym: %q\n", it.Sym)
+		return
+	case "name":
+		if n := ItemName(it); n != "" {
+			fmt.Fprintf(w, "  item name: matched %q\n", n)
+			return
+		}
+		fmt.Fprintf(w, "  item name: not present\n")
+	}
+
+	if hasDef && def.expr != nil {
+		for _, ref := range def.expr.Refs() {
+			if v, ok := NumericValue(ref, it, m); ok {
+				fmt.Fprintf(w, "  ref %s: %s\n", ref, strconv.FormatFloat(v, 'g', -1, 64))
+			} else {
+				fmt.Fprintf(w, "  ref %s: missing\n", ref)
+			}
+		}
+	}
+
+	if hasDef && def.Render != nil {
+		val := strings.TrimSpace(def.Render(CellContext{Key: key, Item: it, Raw: m}))
+		fmt.Fprintf(w, "  render: custom renderer -> %q\n", val)
+		return
+	}
+
+	if hasDef && strings.TrimSpace(def.Path) != "" {
+		matched := false
+		for _, alt := range strings.Split(def.Path, "|") {
+			alt = strings.TrimSpace(alt)
+			if alt == "" {
+				continue
+			}
+			if matched {
+				fmt.Fprintf(w, "  path %s: skipped\n", alt)
+				continue
+			}
+			if v, ok := Extract(m, alt); ok {
+				fmt.Fprintf(w, "  path %s: matched %q\n", alt, v)
+				matched = true
+				continue
+			}
+			if _, ok := walkOnce(m, alt); ok {
+				fmt.Fprintf(w, "  path %s: present but empty\n", alt)
+			} else {
+				fmt.Fprintf(w, "  path %s: no match\n", alt)
+			}
+		}
+		if matched {
+			return
+		}
+	}
+
+	// Custom YAML fields: exact key first, then case-insensitive.
+	if v, ok := it.Fields[key]; ok && v != nil {
+		fmt.Fprintf(w, "  yaml %s: matched %q\n", key, strings.TrimSpace(fmt.Sprint(v)))
+		return
+	}
+	for k, v := range it.Fields {
+		if strings.EqualFold(k, key) && v != nil {
+			fmt.Fprintf(w, "  yaml %s: matched %q (as %s)\n", key, strings.TrimSpace(fmt.Sprint(v)), k)
+			return
+		}
+	}
+	fmt.Fprintf(w, "  yaml %s: not present\n", key)
+	fmt.Fprintf(w, "  result: blank\n")
+}
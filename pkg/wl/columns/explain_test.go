@@ -0,0 +1,55 @@
+package columns
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func explain(col string, it types.Item, m map[string]any) string {
+	var buf bytes.Buffer
+	Explain(&buf, col, it, m)
+	return buf.String()
+}
+
+func TestExplainPathFallbacks(t *testing.T) {
+	it := types.Item{Sym: "AAPL"}
+	matched := explain("name", it, map[string]any{"price": map[string]any{"longName": "Apple Inc."}})
+	for _, want := range []string{
+		"AAPL: col=name key=name registered=true module=price\n",
+		"  item name: not present\n",
+		"  path price.shortName: no match\n",
+		"  path price.longName: matched \"Apple Inc.\"\n",
+	} {
+		if !strings.Contains(matched, want) {
+			t.Errorf("matched trace missing %q:\n%s", want, matched)
+		}
+	}
+	if strings.Contains(matched, "result: blank") {
+		t.Errorf("matched trace reports blank:\n%s", matched)
+	}
+
+	unmatched := explain("sector", it, map[string]any{})
+	for _, want := range []string{
+		"AAPL: col=sector key=sector registered=true module=assetProfile\n",
+		"  path assetProfile.sector: no match\n",
+		"  yaml sector: not present\n",
+		"  result: blank\n",
+	} {
+		if !strings.Contains(unmatched, want) {
+			t.Errorf("unmatched trace missing %q:\n%s", want, unmatched)
+		}
+	}
+}
+
+func TestExplainFallsBackToYAMLField(t *testing.T) {
+	out := explain("thesis", types.Item{Sym: "MSFT", Fields: map[string]any{"Thesis": "cloud"}}, nil)
+	if !strings.Contains(out, "registered=false module=-") || !strings.Contains(out, `yaml thesis: matched "cloud" (as Thesis)`) {
+		t.Errorf("trace = %s", out)
+	}
+	if got := explain("pnl", types.Item{Sym: "MSFT"}, nil); !strings.Contains(got, "module=price\n") {
+		t.Errorf("pnl trace should name the price module it requires:\n%s", got)
+	}
+}
@@ -0,0 +1,332 @@
+package columns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// Expr is a parsed arithmetic expression over column values, e.g.
+// "(tgt_mean - price)/price*100". It supports numbers, column references,
+// + - * /, unary minus and parentheses.
+type Expr struct {
+	root exprNode
+	refs []string
+}
+
+type exprNode interface {
+	eval(lookup func(string) (float64, bool)) (float64, bool)
+}
+
+type numNode float64
+
+type refNode string
+
+type negNode struct{ x exprNode }
+
+type binNode struct {
+	op   byte
+	l, r exprNode
+}
+
+func (n numNode) eval(func(string) (float64, bool)) (float64, bool) { return float64(n), true }
+
+func (n refNode) eval(lookup func(string) (float64, bool)) (float64, bool) { return lookup(string(n)) }
+
+func (n negNode) eval(lookup func(string) (float64, bool)) (float64, bool) {
+	v, ok := n.x.eval(lookup)
+	return -v, ok
+}
+
+func (n binNode) eval(lookup func(string) (float64, bool)) (float64, bool) {
+	l, ok := n.l.eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	r, ok := n.r.eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	switch n.op {
+	case '+':
+		return l + r, true
+	case '-':
+		return l - r, true
+	case '*':
+		return l * r, true
+	default:
+		if r == 0 {
+			return 0, false
+		}
+		return l / r, true
+	}
+}
+
+// ParseExpr parses s. Column names may contain letters, digits, '_', '.'
+// and '%' (so "chg%" and "52w_high" are references); a token that parses as
+// a number is a constant.
+func ParseExpr(s string) (*Expr, error) {
+	p := &exprParser{src: s}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.toks) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.toks) {
+		return nil, fmt.Errorf("unexpected %q", p.toks[p.pos])
+	}
+	return &Expr{root: root, refs: p.refs}, nil
+}
+
+// Refs returns the column names referenced by e, in first-use order.
+func (e *Expr) Refs() []string { return append([]string(nil), e.refs...) }
+
+// Eval evaluates e, resolving references through lookup. It reports false
+// when a reference has no value or a division by zero occurs.
+func (e *Expr) Eval(lookup func(name string) (float64, bool)) (float64, bool) {
+	return e.root.eval(lookup)
+}
+
+type exprParser struct {
+	src  string
+	toks []string
+	pos  int
+	refs []string
+}
+
+func isExprIdentRune(r byte) bool {
+	return r == '_' || r == '.' || r == '%' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func (p *exprParser) tokenize() error {
+	s := p.src
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case strings.IndexByte("+-*/()", c) >= 0:
+			p.toks = append(p.toks, string(c))
+			i++
+		case isExprIdentRune(c):
+			j := i
+			for j < len(s) && isExprIdentRune(s[j]) {
+				j++
+			}
+			p.toks = append(p.toks, s[i:j])
+			i = j
+		default:
+			return fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return nil
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return ""
+}
+
+// parseSum := product (('+'|'-') product)*
+func (p *exprParser) parseSum() (exprNode, error) {
+	l, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "+" || op == "-"; op = p.peek() {
+		p.pos++
+		r, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		l = binNode{op: op[0], l: l, r: r}
+	}
+	return l, nil
+}
+
+// parseProduct := unary (('*'|'/') unary)*
+func (p *exprParser) parseProduct() (exprNode, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == "*" || op == "/"; op = p.peek() {
+		p.pos++
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = binNode{op: op[0], l: l, r: r}
+	}
+	return l, nil
+}
+
+// parseUnary := '-' unary | '(' sum ')' | number | column
+func (p *exprParser) parseUnary() (exprNode, error) {
+	tok := p.peek()
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case "-":
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negNode{x: x}, nil
+	case "(":
+		p.pos++
+		x, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return x, nil
+	case "+", "*", "/", ")":
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+	p.pos++
+	if f, err := strconv.ParseFloat(tok, 64); err == nil {
+		return numNode(f), nil
+	}
+	name := strings.ToLower(tok)
+	seen := false
+	for _, r := range p.refs {
+		seen = seen || r == name
+	}
+	if !seen {
+		p.refs = append(p.refs, name)
+	}
+	return refNode(name), nil
+}
+
+// RegisterComputed registers key as a column computed from expr (see
+// ParseExpr). References resolve to registered columns or YAML fields, and
+// the column fetches every module its references need. The cell shows the
+// result with two decimals, or as a percentage (see FormatPercent) when key
+// ends in '%', and is blank when any referenced value is missing. Computed
+// columns cannot reference each other or replace a built-in column.
+func RegisterComputed(key, expr string) error {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" {
+		return fmt.Errorf("computed column has no name")
+	}
+	if k, ok := Canonical(key); ok {
+		if def, ok := GetDef(k); ok && def.expr == nil {
+			return fmt.Errorf("computed column %s: conflicts with built-in column %s", key, k)
+		}
+	}
+	e, err := ParseExpr(expr)
+	if err != nil {
+		return fmt.Errorf("computed column %s: %w", key, err)
+	}
+	var mods []yfgo.QuoteSummaryModule
+	for _, ref := range e.refs {
+		if ref == key {
+			return fmt.Errorf("computed column %s: cannot reference itself", key)
+		}
+		k, ok := Canonical(ref)
+		if !ok {
+			continue // YAML field
+		}
+		def, ok := GetDef(k)
+		if !ok {
+			continue
+		}
+		if def.expr != nil {
+			return fmt.Errorf("computed column %s: cannot reference computed column %s", key, ref)
+		}
+		for _, m := range def.Modules() {
+			if !containsModule(mods, m) {
+				mods = append(mods, m)
+			}
+		}
+	}
+	def := ColumnDef{Key: key, Align: AlignRight, expr: e}
+	if len(mods) > 0 {
+		def.Module, def.Requires = mods[0], mods[1:]
+	}
+	def.Render = func(ctx CellContext) string {
+		v, ok := e.Eval(func(name string) (float64, bool) { return NumericValue(name, ctx.Item, ctx.Raw) })
+		if !ok {
+			return ""
+		}
+		if strings.HasSuffix(key, "%") {
+			return FormatPercent(v, PercentDecimals)
+		}
+		return FormatFloat(v, 2)
+	}
+	RegisterDef(def)
+	return nil
+}
+
+// NumericValue returns col's numeric value for an item: the ".raw"
+// counterpart of a ".fmt" path when there is one, else the displayed value
+// or YAML field parsed as a plain number (commas and a trailing '%'
+// allowed, so "12.3%" is 12.3). Percentages are in percent as displayed,
+// like alert thresholds: Yahoo's fractions are scaled, so chg% showing
+// "1.20%" is 1.2 rather than 0.012.
+func NumericValue(col string, it types.Item, m map[string]any) (float64, bool) {
+	key, _ := Canonical(col)
+	if def, ok := GetDef(key); ok {
+		if def.Value != nil {
+			return def.Value(CellContext{Key: key, Item: it, Raw: m})
+		}
+		if strings.Contains(def.Path, ".fmt") {
+			if v, ok := Extract(m, strings.ReplaceAll(def.Path, ".fmt", ".raw")); ok {
+				if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					if disp, ok := Extract(m, def.Path); ok && strings.HasSuffix(strings.TrimSpace(disp), "%") {
+						f *= 100
+					}
+					return f, true
+				}
+			}
+		}
+		if def.Path != "" {
+			if v, ok := Extract(m, def.Path); ok {
+				return parsePlainNumber(v)
+			}
+		}
+		if def.Render != nil {
+			return parsePlainNumber(def.Render(CellContext{Key: key, Item: it, Raw: m}))
+		}
+	}
+	for k, v := range it.Fields {
+		if strings.EqualFold(k, key) && v != nil {
+			return parsePlainNumber(fmt.Sprint(v))
+		}
+	}
+	return 0, false
+}
+
+func parsePlainNumber(s string) (float64, bool) {
+	s = strings.TrimSuffix(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), "%")
+	if s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	return f, err == nil
+}
+
+func containsModule(mods []yfgo.QuoteSummaryModule, m yfgo.QuoteSummaryModule) bool {
+	for _, x := range mods {
+		if x == m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package columns
+
+import (
+	"math"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestComputedSeesDerivedColumns(t *testing.T) {
+	raw := map[string]any{
+		"price":         map[string]any{"regularMarketPrice": map[string]any{"raw": 90.0}},
+		"summaryDetail": map[string]any{"fiftyTwoWeekHigh": map[string]any{"raw": 120.0}},
+	}
+	it := types.Item{Sym: "ZZZ", Fields: map[string]any{"cost": 60, "shares": "1,000"}}
+	for _, tc := range []struct {
+		key, expr string
+		want      float64
+	}{
+		{"test_off_high2", "off_high% * 2", -50},
+		{"test_pnl_k", "pnl / 1000", 30},
+		{"test_value_per_share", "mkt_value / shares", 90},
+	} {
+		if err := RegisterComputed(tc.key, tc.expr); err != nil {
+			t.Fatalf("RegisterComputed(%s): %v", tc.expr, err)
+		}
+		got, ok := NumericValue(tc.key, it, raw)
+		if !ok || math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s = %v, %v; want %v", tc.expr, got, ok, tc.want)
+		}
+	}
+	// missing inputs still blank the cell rather than read as zero
+	def, _ := GetDef("test_off_high2")
+	if got := def.Render(CellContext{Key: "test_off_high2", Item: it, Raw: map[string]any{}}); got != "" {
+		t.Errorf("off_high%% * 2 without a high = %q, want blank", got)
+	}
+}
+
+func TestDerivedRenderMatchesValue(t *testing.T) {
+	raw := map[string]any{
+		"price":         map[string]any{"regularMarketPrice": map[string]any{"raw": 90.0}},
+		"summaryDetail": map[string]any{"fiftyTwoWeekLow": map[string]any{"raw": 60.0}, "fiftyTwoWeekHigh": map[string]any{"raw": 120.0}},
+	}
+	ctx := CellContext{Key: "range52", Raw: raw}
+	def, _ := GetDef("range52")
+	if v, ok := def.Value(ctx); !ok || v != 50 {
+		t.Errorf("range52 value = %v, %v; want 50", v, ok)
+	}
+	if got, want := def.Render(ctx), FormatPercent(50, PercentDecimals); got != want {
+		t.Errorf("range52 render = %q, want %q", got, want)
+	}
+}
+
+func TestComputedRejectsSelfReference(t *testing.T) {
+	for _, expr := range []string{"test_self% + 1", "price / TEST_SELF%"} {
+		if err := RegisterComputed("test_self%", expr); err == nil {
+			t.Errorf("RegisterComputed(test_self%%, %q) succeeded, want a self-reference error", expr)
+		}
+	}
+	if _, ok := Canonical("test_self%"); ok {
+		t.Error("rejected computed column was registered")
+	}
+}
+
+func TestNumericValuePercentAsDisplayed(t *testing.T) {
+	raw := map[string]any{
+		"price":         map[string]any{"regularMarketChangePercent": map[string]any{"raw": 0.012, "fmt": "1.20%"}},
+		"financialData": map[string]any{"debtToEquity": map[string]any{"raw": 150.3, "fmt": "150.30"}},
+	}
+	for col, want := range map[string]float64{"chg%": 1.2, "de%": 150.3} {
+		if got, ok := NumericValue(col, types.Item{}, raw); !ok || math.Abs(got-want) > 1e-9 {
+			t.Errorf("NumericValue(%s) = %v, %v; want %v", col, got, ok, want)
+		}
+	}
+}
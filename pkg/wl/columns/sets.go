@@ -7,6 +7,9 @@ import "strings"
 // User config can merge/override in cmd/wl/main.go.
 var Sets = map[string][]string{}
 
+// QuickColumns is the --quick preset; every column is served by the price module.
+var QuickColumns = []string{"sym", "name", "price", "chg%"}
+
 // BuildDefaultSetsFromDefs populates Sets with one set per module from ColumnDef.
 // Excludes the "base" group (non-Yahoo backed fields like sym/name unless mapped).
 func BuildDefaultSetsFromDefs() {
@@ -57,6 +60,58 @@ func ExpandSets(setNames []string) ([]string, error) {
 	return out, nil
 }
 
+// ApplySelection applies column tokens to an accumulated column list, in order:
+//   - "module.*" appends every column of that Yahoo module (e.g. "price.*")
+//   - "-col" removes col (matched by canonical key) from what has accumulated so far
+//   - anything else is appended as-is
+//
+// Appends are de-duplicated, keeping the first occurrence.
+func ApplySelection(acc []string, tokens []string) []string {
+	out := append([]string(nil), acc...)
+	has := func(c string) bool {
+		for _, e := range out {
+			if e == c {
+				return true
+			}
+		}
+		return false
+	}
+	for _, tok := range tokens {
+		tok = strings.TrimSpace(tok)
+		if tok == "" {
+			continue
+		}
+		if strings.HasPrefix(tok, "-") {
+			drop, _ := Canonical(strings.TrimPrefix(tok, "-"))
+			kept := out[:0]
+			for _, c := range out {
+				if k, _ := Canonical(c); k != drop {
+					kept = append(kept, c)
+				}
+			}
+			out = kept
+			continue
+		}
+		if mod, ok := strings.CutSuffix(tok, ".*"); ok {
+			for name, cols := range AvailableByModule() {
+				if !strings.EqualFold(name, mod) {
+					continue
+				}
+				for _, c := range cols {
+					if !has(c) {
+						out = append(out, c)
+					}
+				}
+			}
+			continue
+		}
+		if !has(tok) {
+			out = append(out, tok)
+		}
+	}
+	return out
+}
+
 // UnknownSetError reports an unknown column set name.
 type UnknownSetError struct {
 	Name      string
@@ -0,0 +1,32 @@
+package columns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplySelectionModuleGlobWithExclusion(t *testing.T) {
+	all := AvailableByModule()["summaryDetail"]
+	if !contains(all, "vol") || !contains(all, "range52") {
+		t.Fatalf("summaryDetail set = %v, want vol and range52 in it", all)
+	}
+	got := ApplySelection([]string{"sym"}, []string{"summaryDetail.*", "-vol", "-pct_of_52w_range"})
+	if got[0] != "sym" || len(got) != len(all)-1 {
+		t.Fatalf("got %d columns %v, want sym plus summaryDetail minus 2", len(got), got)
+	}
+	if contains(got, "vol") || contains(got, "range52") {
+		t.Errorf("exclusions kept: %v", got)
+	}
+	for _, c := range all {
+		if c != "vol" && c != "range52" && !contains(got, c) {
+			t.Errorf("%s missing from %v", c, got)
+		}
+	}
+	// order matters: a later token adds the column back, and a glob is case-insensitive
+	if got := ApplySelection(nil, []string{"SummaryDetail.*", "-vol", "vol"}); !reflect.DeepEqual(got[len(got)-1:], []string{"vol"}) || len(got) != len(all) {
+		t.Errorf("re-added vol: %v", got)
+	}
+	if got := ApplySelection([]string{"sym", "price"}, []string{"-price", "nosuch.*", "sym"}); !reflect.DeepEqual(got, []string{"sym"}) {
+		t.Errorf("got %v, want [sym]", got)
+	}
+}
@@ -90,3 +90,113 @@ func (s SubstrCI) Match(name string) bool {
 }
 
 func (s SubstrCI) String() string { return fmt.Sprintf("substr-ci:%s", s.needle) }
+
+// All combines filters with AND, e.g. a configured default filter and the
+// one given on the command line.
+type All []Filter
+
+// Match reports whether every filter in a matches name; an empty All
+// matches everything.
+func (a All) Match(name string) bool {
+	for _, f := range a {
+		if !f.Match(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// ItemTags returns the item's tags from its "tags" field, which may be a YAML
+// list or a comma-separated string.
+func ItemTags(fields map[string]any) []string {
+	var v any
+	for k, val := range fields {
+		if strings.EqualFold(k, "tags") {
+			v = val
+			break
+		}
+	}
+	switch t := v.(type) {
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, e := range t {
+			if e == nil {
+				continue
+			}
+			if s := strings.TrimSpace(fmt.Sprint(e)); s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	case string:
+		var out []string
+		for _, p := range strings.Split(t, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				out = append(out, p)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// HasAnyTag reports whether fields carry any of the wanted tags, case-insensitively.
+// An empty want list matches everything.
+func HasAnyTag(fields map[string]any, want []string) bool {
+	if len(want) == 0 {
+		return true
+	}
+	for _, t := range ItemTags(fields) {
+		for _, w := range want {
+			if strings.EqualFold(t, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SymbolSet matches item symbols against a selection, case-insensitively.
+// A selector without an exchange suffix (e.g. "7203") also matches any
+// suffixed symbol with that base ("7203.T"); a suffixed selector matches
+// exactly.
+type SymbolSet struct {
+	exact map[string]bool
+	bare  map[string]bool
+}
+
+// NewSymbolSet builds a SymbolSet from selectors; blanks are ignored.
+func NewSymbolSet(syms []string) SymbolSet {
+	s := SymbolSet{exact: map[string]bool{}, bare: map[string]bool{}}
+	for _, sym := range syms {
+		sym = strings.ToUpper(strings.TrimSpace(sym))
+		if sym == "" {
+			continue
+		}
+		if strings.Contains(sym, ".") {
+			s.exact[sym] = true
+		} else {
+			s.bare[sym] = true
+		}
+	}
+	return s
+}
+
+// Empty reports whether the set has no selectors.
+func (s SymbolSet) Empty() bool { return len(s.exact) == 0 && len(s.bare) == 0 }
+
+// Match reports whether sym is selected.
+func (s SymbolSet) Match(sym string) bool {
+	sym = strings.ToUpper(strings.TrimSpace(sym))
+	if sym == "" {
+		return false
+	}
+	if s.exact[sym] || s.bare[sym] {
+		return true
+	}
+	if i := strings.LastIndex(sym, "."); i > 0 {
+		return s.bare[sym[:i]]
+	}
+	return false
+}
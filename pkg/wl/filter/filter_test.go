@@ -0,0 +1,92 @@
+package filter
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		expr, name string
+		want       bool
+	}{
+		{"", "anything", true},
+		{"tech", "us/Tech", true},
+		{"tech", "us/banks", false},
+		{"core,us/tech", "us/tech", true},
+		{"core,us/tech", "us/tech2", false},
+		{"us/*", "us/tech", true},
+		{"us/*", "jp/autos", false},
+		{"/^archive-/", "archive-2023", true},
+		{"/^archive-/", "core-archive", false},
+	}
+	for _, c := range cases {
+		f, err := Parse(c.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.expr, err)
+		}
+		if got := f.Match(c.name); got != c.want {
+			t.Errorf("Parse(%q).Match(%q) = %v, want %v", c.expr, c.name, got, c.want)
+		}
+	}
+	if _, err := Parse("/[/"); err == nil {
+		t.Error("Parse(\"/[/\"): expected a regexp error")
+	}
+}
+
+func TestAll(t *testing.T) {
+	def, _ := Parse("core")
+	cli, _ := Parse("archive")
+	f := All{def, cli}
+	for name, want := range map[string]bool{"core-archive": true, "core": false, "archive-2023": false} {
+		if got := f.Match(name); got != want {
+			t.Errorf("All.Match(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if !(All{}).Match("x") {
+		t.Error("empty All should match everything")
+	}
+}
+
+func TestHasAnyTag(t *testing.T) {
+	cases := []struct {
+		fields map[string]any
+		want   []string
+		match  bool
+	}{
+		{map[string]any{"tags": []any{"growth", "Dividend"}}, []string{"dividend"}, true},
+		{map[string]any{"Tags": []any{"GROWTH"}}, []string{"growth"}, true},
+		{map[string]any{"tags": []any{"growth", nil, 5}}, []string{"5"}, true},
+		{map[string]any{"tags": "growth, dividend"}, []string{"DIVIDEND"}, true},
+		{map[string]any{"tags": []any{"growth"}}, []string{"dividend", "value"}, false},
+		{map[string]any{"tags": []any{"dividend-aristocrat"}}, []string{"dividend"}, false},
+		{map[string]any{"sector": "tech"}, []string{"tech"}, false},
+		{nil, nil, true},
+	}
+	for _, c := range cases {
+		if got := HasAnyTag(c.fields, c.want); got != c.match {
+			t.Errorf("HasAnyTag(%v, %v) = %v, want %v", c.fields, c.want, got, c.match)
+		}
+	}
+}
+
+func TestSymbolSet(t *testing.T) {
+	s := NewSymbolSet([]string{" aapl", "7203", "VOD.L", ""})
+	for sym, want := range map[string]bool{
+		"AAPL":    true,
+		"aapl":    true,
+		"7203.T":  true, // bare selector matches any suffix
+		"7203":    true,
+		"VOD.L":   true,
+		"vod.l":   true,
+		"VOD":     false, // a suffixed selector matches exactly
+		"VOD.AS":  false,
+		"AAPL.MX": true,
+		"MSFT":    false,
+		"":        false,
+	} {
+		if got := s.Match(sym); got != want {
+			t.Errorf("Match(%q) = %v, want %v", sym, got, want)
+		}
+	}
+	if !NewSymbolSet(nil).Empty() || !NewSymbolSet([]string{" "}).Empty() || s.Empty() {
+		t.Error("Empty is wrong")
+	}
+}
@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// DedupeItems removes items whose symbol repeats an earlier one, comparing
+// case-insensitively. The first occurrence is kept and later duplicates only
+// fill in fields it lacks. Items without a symbol are always kept. The
+// caller's items and their Fields maps are never modified.
+func DedupeItems(items []types.Item) []types.Item {
+	out := make([]types.Item, 0, len(items))
+	first := map[string]int{}
+	owned := map[int]bool{} // kept items whose Fields were already copied
+	for _, it := range items {
+		key := strings.ToUpper(strings.TrimSpace(it.Sym))
+		if key == "" {
+			out = append(out, it)
+			continue
+		}
+		i, dup := first[key]
+		if !dup {
+			first[key] = len(out)
+			out = append(out, it)
+			continue
+		}
+		kept := &out[i]
+		if kept.Name == "" {
+			kept.Name = it.Name
+		}
+		for k, v := range it.Fields {
+			if _, ok := kept.Fields[k]; ok {
+				continue
+			}
+			if !owned[i] {
+				// Copy before the first write: the map is shared with the
+				// caller's item, which other lists may also hold
+				fields := make(map[string]any, len(kept.Fields)+len(it.Fields))
+				for fk, fv := range kept.Fields {
+					fields[fk] = fv
+				}
+				kept.Fields = fields
+				owned[i] = true
+			}
+			kept.Fields[k] = v
+		}
+	}
+	return out
+}
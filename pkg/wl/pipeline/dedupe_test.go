@@ -0,0 +1,38 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestDedupeItems(t *testing.T) {
+	items := []types.Item{
+		{Sym: "AAPL", Fields: map[string]any{"note": "first"}},
+		{Sym: "MSFT"},
+		{Sym: "aapl ", Name: "Apple", Fields: map[string]any{"note": "second", "tags": "core"}},
+		{Sym: ""},
+		{Sym: ""},
+	}
+	got := DedupeItems(items)
+	if want := []string{"AAPL", "MSFT", "", ""}; !reflect.DeepEqual(syms(got), want) {
+		t.Fatalf("syms = %v, want %v", syms(got), want)
+	}
+	want := map[string]any{"note": "first", "tags": "core"}
+	if !reflect.DeepEqual(got[0].Fields, want) || got[0].Name != "Apple" {
+		t.Fatalf("kept item = %+v, want fields %v filled from the duplicate", got[0], want)
+	}
+}
+
+func TestDedupeItemsLeavesInputFieldsAlone(t *testing.T) {
+	shared := map[string]any{"note": "core"}
+	items := []types.Item{
+		{Sym: "AAPL", Fields: shared},
+		{Sym: "AAPL", Fields: map[string]any{"cost": 150}},
+	}
+	DedupeItems(items)
+	if _, leaked := shared["cost"]; leaked || len(shared) != 1 {
+		t.Fatalf("caller's Fields map was modified: %v", shared)
+	}
+}
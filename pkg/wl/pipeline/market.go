@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// MarketOpen reports whether any Yahoo price.marketState indicates an active
+// session (PRE, REGULAR or POST). Empty/unknown states count as open so a
+// failed probe never stalls refreshing.
+func MarketOpen(states []string) bool {
+	if len(states) == 0 {
+		return true
+	}
+	for _, s := range states {
+		switch strings.ToUpper(strings.TrimSpace(s)) {
+		case "PRE", "REGULAR", "POST":
+			return true
+		case "CLOSED", "PREPRE", "POSTPOST":
+			continue
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// MarketSchedule is a configured weekday trading session in a time zone,
+// used instead of probing marketState when set.
+type MarketSchedule struct {
+	Location    *time.Location
+	Open, Close time.Duration // offsets from local midnight
+}
+
+// ParseMarketSchedule parses a time zone name and "HH:MM" open/close times.
+func ParseMarketSchedule(tz, open, closeAt string) (MarketSchedule, error) {
+	loc, err := time.LoadLocation(strings.TrimSpace(tz))
+	if err != nil {
+		return MarketSchedule{}, fmt.Errorf("market hours tz: %w", err)
+	}
+	parse := func(s string) (time.Duration, error) {
+		t, err := time.Parse("15:04", strings.TrimSpace(s))
+		if err != nil {
+			return 0, fmt.Errorf("market hours time %q: expected HH:MM", s)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	o, err := parse(open)
+	if err != nil {
+		return MarketSchedule{}, err
+	}
+	c, err := parse(closeAt)
+	if err != nil {
+		return MarketSchedule{}, err
+	}
+	if c <= o {
+		return MarketSchedule{}, fmt.Errorf("market hours close %s must be after open %s", closeAt, open)
+	}
+	return MarketSchedule{Location: loc, Open: o, Close: c}, nil
+}
+
+// IsOpen reports whether t falls within the session on a weekday.
+func (s MarketSchedule) IsOpen(t time.Time) bool {
+	lt := t.In(s.Location)
+	if wd := lt.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	midnight := time.Date(lt.Year(), lt.Month(), lt.Day(), 0, 0, 0, 0, s.Location)
+	off := lt.Sub(midnight)
+	return off >= s.Open && off < s.Close
+}
+
+// ProbeSymbols picks one symbol per exchange suffix (".T", ".L", none for US)
+// so market state can be checked with a handful of requests.
+func ProbeSymbols(lists []types.Watchlist) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, l := range lists {
+		for _, it := range l.Items {
+			sym := strings.TrimSpace(it.Sym)
+			if sym == "" {
+				continue
+			}
+			suffix := ""
+			if i := strings.LastIndex(sym, "."); i > 0 {
+				suffix = strings.ToUpper(sym[i:])
+			}
+			if !seen[suffix] {
+				seen[suffix] = true
+				out = append(out, sym)
+			}
+		}
+	}
+	return out
+}
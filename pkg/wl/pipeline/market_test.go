@@ -0,0 +1,71 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestMarketOpen(t *testing.T) {
+	cases := []struct {
+		states []string
+		want   bool
+	}{
+		{[]string{"REGULAR"}, true},
+		{[]string{"pre"}, true},
+		{[]string{"POST"}, true},
+		{[]string{"CLOSED"}, false},
+		{[]string{"PREPRE", "POSTPOST", "CLOSED"}, false},
+		{[]string{"CLOSED", "REGULAR"}, true}, // one exchange open is enough
+		{[]string{"CLOSED", ""}, true},        // a failed probe never stalls refreshing
+		{nil, true},
+	}
+	for _, c := range cases {
+		if got := MarketOpen(c.states); got != c.want {
+			t.Errorf("MarketOpen(%q) = %v, want %v", c.states, got, c.want)
+		}
+	}
+}
+
+func TestMarketSchedule(t *testing.T) {
+	s, err := ParseMarketSchedule("America/New_York", "09:30", "16:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny := s.Location
+	cases := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 10, 16, 9, 29, 0, 0, ny), false}, // Friday, before the open
+		{time.Date(2026, 10, 16, 9, 30, 0, 0, ny), true},
+		{time.Date(2026, 10, 16, 15, 59, 0, 0, ny), true},
+		{time.Date(2026, 10, 16, 16, 0, 0, 0, ny), false},
+		{time.Date(2026, 10, 17, 12, 0, 0, 0, ny), false},                            // Saturday
+		{time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC), true},                       // 10:00 in New York
+		{time.Date(2026, 10, 16, 21, 0, 0, 0, time.FixedZone("JST", 9*3600)), false}, // 08:00 in New York
+	}
+	for _, c := range cases {
+		if got := s.IsOpen(c.at); got != c.want {
+			t.Errorf("IsOpen(%s) = %v, want %v", c.at, got, c.want)
+		}
+	}
+	for _, bad := range [][3]string{{"Nowhere/City", "09:00", "17:00"}, {"UTC", "9am", "17:00"}, {"UTC", "17:00", "09:00"}} {
+		if _, err := ParseMarketSchedule(bad[0], bad[1], bad[2]); err == nil {
+			t.Errorf("ParseMarketSchedule(%q): want an error", bad)
+		}
+	}
+}
+
+func TestProbeSymbols(t *testing.T) {
+	lists := []types.Watchlist{
+		list("us", "AAPL", "MSFT", "7203.T"),
+		list("mixed", "6758.t", "VOD.L", "", "BRK.B"),
+	}
+	want := []string{"AAPL", "7203.T", "VOD.L", "BRK.B"}
+	if got := ProbeSymbols(lists); !reflect.DeepEqual(got, want) {
+		t.Errorf("ProbeSymbols = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package pipeline
+
+import (
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// MergeLists combines watchlists sharing an identical Name, keeping the
+// position of the first occurrence. Items are concatenated and de-duplicated
+// by Sym, compared case-insensitively (first wins; items without a symbol are
+// always kept), and columns are unioned in first-seen order. ColSet cannot be
+// unioned meaningfully, so the first list's col_set applies to the merge.
+func MergeLists(lists []types.Watchlist) []types.Watchlist {
+	out := make([]types.Watchlist, 0, len(lists))
+	index := map[string]int{}
+	seenSym := map[string]map[string]bool{}
+	seenCol := map[string]map[string]bool{}
+	for _, l := range lists {
+		i, ok := index[l.Name]
+		if !ok {
+			i = len(out)
+			index[l.Name] = i
+			out = append(out, types.Watchlist{Name: l.Name, ColSet: l.ColSet})
+			seenSym[l.Name] = map[string]bool{}
+			seenCol[l.Name] = map[string]bool{}
+		}
+		for _, c := range l.Columns {
+			if !seenCol[l.Name][c] {
+				seenCol[l.Name][c] = true
+				out[i].Columns = append(out[i].Columns, c)
+			}
+		}
+		for _, it := range l.Items {
+			if sym := strings.ToUpper(strings.TrimSpace(it.Sym)); sym != "" {
+				if seenSym[l.Name][sym] {
+					continue
+				}
+				seenSym[l.Name][sym] = true
+			}
+			out[i].Items = append(out[i].Items, it)
+		}
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+package pipeline
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestMergeLists(t *testing.T) {
+	a := list("tech", "AAPL", "MSFT")
+	a.Columns = []string{"sym", "price"}
+	a.ColSet = []string{"price"}
+	b := list("tech", "aapl", "NVDA")
+	b.Columns = []string{"sym", "note"}
+	b.ColSet = []string{"summaryDetail"}
+	got := MergeLists([]types.Watchlist{a, list("jp", "7203.T"), b})
+	if len(got) != 2 || got[0].Name != "tech" || got[1].Name != "jp" {
+		t.Fatalf("lists = %+v, want tech then jp", got)
+	}
+	if want := []string{"AAPL", "MSFT", "NVDA"}; !reflect.DeepEqual(syms(got[0].Items), want) {
+		t.Errorf("syms = %v, want %v", syms(got[0].Items), want)
+	}
+	if want := []string{"sym", "price", "note"}; !reflect.DeepEqual(got[0].Columns, want) {
+		t.Errorf("columns = %v, want %v", got[0].Columns, want)
+	}
+	if want := []string{"price"}; !reflect.DeepEqual(got[0].ColSet, want) {
+		t.Errorf("col_set = %v, want the first list's %v", got[0].ColSet, want)
+	}
+}
@@ -2,7 +2,9 @@ package pipeline
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/komsit37/wl/pkg/wl/columns"
 	"github.com/komsit37/wl/pkg/wl/filter"
@@ -20,20 +22,113 @@ type Runner struct {
 type ExecuteOptions struct {
 	Columns     []string
 	Filter      filter.Filter
+	Tags        []string // keep only items tagged with any of these
+	Select      []string // keep only these symbols (suffix-insensitive)
+	KeepEmpty   bool     // keep lists left empty by Tags/Select
+	MergeLists  bool     // combine lists sharing the same name
+	Dedupe      bool     // drop repeated symbols within each list
 	Color       bool
 	PrettyJSON  bool
 	MaxColWidth int
 	TermWidth   int
+	NoHeader    bool
+	// AlignDecimals pads numeric columns so decimal points line up
+	AlignDecimals bool
+	// Syms output
+	SymsSep         string
+	SymsStripSuffix string
+	SymsPerList     bool
 	// Sorting
-	SortBy   string
-	SortDesc bool
+	SortBy      string
+	SortDesc    bool
+	SortMissing string // "first" or "last" (default)
+	GroupBy     string
+	// MaxSymbols aborts before rendering when the filtered lists hold more
+	// symbols in total; 0 means unlimited.
+	MaxSymbols int
+	// SparkDays is the number of closes drawn by the spark column
+	SparkDays int
+	// Concurrency caps simultaneous per-symbol fetches
+	Concurrency int
+	// BatchSize groups price-only fetches into multi-symbol requests; 0 is off
+	BatchSize int
+	// FetchRetries retries transient fetch errors per symbol
+	FetchRetries int
+	// Progress is told how many items of a list have been fetched
+	Progress func(done, total int)
+	// FetchFailed hears about each item whose quote fetch failed
+	FetchFailed func(render.FetchError)
+	// Notice hears non-fatal notes about the output
+	Notice func(string)
+	// Heatmap
+	Heatmap     []string
+	HeatmapLow  string
+	HeatmapHigh string
+	// JSONMeta adds generated_at/source to JSON output; nil omits them
+	JSONMeta *render.JSONMeta
+	// JSONRaw writes only YAML fields in JSON output, fetching nothing
+	JSONRaw bool
+	// JSONBySym keys JSON output by symbol instead of by list
+	JSONBySym bool
+	// View labels and widths keyed by canonical column key
+	ColumnLabels map[string]string
+	ColumnWidths map[string]int
+	// ASCII limits terminal output to ASCII characters
+	ASCII bool
+	// Limit caps rendered items per list; 0 is unlimited
+	Limit int
+	// Currency conversion target and static FX rates
+	ConvertTo string
+	FXRates   map[string]float64
+	// Movers: uncolor rows within ±MoverThreshold% chg, or drop them
+	MoverThreshold float64
+	OnlyMovers     bool
+	// AutoFit shrinks table text columns to fit TermWidth
+	AutoFit bool
+	// ColorRules color table cells meeting thresholds from config
+	ColorRules []render.ColorRule
+	// Annotate is a sidecar YAML of per-symbol fields merged into items;
+	// AnnotateOverride lets them replace fields the items already have
+	Annotate         string
+	AnnotateOverride bool
+	// Transpose flips table output: columns as rows, symbols as columns
+	Transpose bool
+	// Theme is the table color scheme
+	Theme string
 }
 
+// Execute loads, filters and renders the watchlists described by spec.
 func (r *Runner) Execute(ctx context.Context, spec any, opts ExecuteOptions) error {
-	lists, err := r.Source.Load(ctx, spec)
+	lists, err := r.Prepare(ctx, spec, opts)
 	if err != nil {
 		return err
 	}
+	return r.Render(ctx, lists, opts)
+}
+
+// Prepare loads the source and applies list/item filters, limits and column
+// computation, returning the lists exactly as they would be rendered.
+func (r *Runner) Prepare(ctx context.Context, spec any, opts ExecuteOptions) ([]types.Watchlist, error) {
+	lists, err := source.Load(ctx, r.Source, spec)
+	if err != nil {
+		return nil, err
+	}
+	// Sidecar fields, re-read on every Prepare so --watch picks up edits
+	if opts.Annotate != "" {
+		ann, err := source.LoadAnnotations(opts.Annotate)
+		if err != nil {
+			return nil, err
+		}
+		ann.Apply(lists, opts.AnnotateOverride)
+	}
+	if opts.MergeLists {
+		lists = MergeLists(lists)
+	}
+	if opts.Dedupe {
+		for i := range lists {
+			lists[i].Items = DedupeItems(lists[i].Items)
+		}
+	}
 
 	// Apply filter by list name
 	var filt filter.Filter = filter.Always(true)
@@ -48,24 +143,129 @@ func (r *Runner) Execute(ctx context.Context, spec any, opts ExecuteOptions) err
 	}
 	lists = filtered
 
+	// Apply item-level tag and symbol filters; lists left empty are dropped
+	// unless KeepEmpty is set
+	if sel := filter.NewSymbolSet(opts.Select); len(opts.Tags) > 0 || !sel.Empty() {
+		kept := make([]types.Watchlist, 0, len(lists))
+		for _, l := range lists {
+			items := make([]types.Item, 0, len(l.Items))
+			for _, it := range l.Items {
+				if !filter.HasAnyTag(it.Fields, opts.Tags) {
+					continue
+				}
+				if !sel.Empty() && !sel.Match(it.Sym) {
+					continue
+				}
+				items = append(items, it)
+			}
+			if len(items) > 0 || opts.KeepEmpty {
+				l.Items = items
+				kept = append(kept, l)
+			}
+		}
+		lists = kept
+	}
+
+	// Guard against accidentally fetching huge directories
+	if opts.MaxSymbols > 0 {
+		total := 0
+		for _, l := range lists {
+			for _, it := range l.Items {
+				if strings.TrimSpace(it.Sym) != "" {
+					total++
+				}
+			}
+		}
+		if total > opts.MaxSymbols {
+			return nil, &render.LimitError{Err: fmt.Errorf("%d symbols exceed --max-symbols %d; narrow with --filter or raise the limit", total, opts.MaxSymbols)}
+		}
+	}
+
 	// Compute columns per list, honoring explicit and overrides
 	for i, l := range lists {
 		var cols []string
-		if len(opts.Columns) > 0 {
+		switch {
+		case len(opts.Columns) > 0:
 			cols = columns.Compute(opts.Columns, l.Items)
-		} else {
+		case len(l.ColSet) > 0:
+			expanded, err := columns.ExpandSets(l.ColSet)
+			if err != nil {
+				return nil, fmt.Errorf("%s: col_set: %w", l.Name, err)
+			}
+			cols = columns.Compute(expanded, l.Items)
+		default:
 			cols = columns.Compute(l.Columns, l.Items)
 		}
 		lists[i].Columns = cols
 	}
+	return lists, nil
+}
 
-	return r.Renderer.Render(r.Writer, lists, render.RenderOptions{
+// Render renders prepared lists to the runner's writer. Fetches stop when
+// ctx is done, leaving the remaining cells blank. Failures are returned as
+// a *render.RenderError.
+func (r *Runner) Render(ctx context.Context, lists []types.Watchlist, opts ExecuteOptions) error {
+	if err := r.Renderer.Render(ctx, r.Writer, lists, opts.RenderOptions()); err != nil {
+		return &render.RenderError{Err: err}
+	}
+	return nil
+}
+
+// RenderOptions returns the subset of opts the renderers use.
+func (opts ExecuteOptions) RenderOptions() render.RenderOptions {
+	return render.RenderOptions{
 		Columns:     opts.Columns,
 		Color:       opts.Color,
 		PrettyJSON:  opts.PrettyJSON,
 		MaxColWidth: opts.MaxColWidth,
 		TermWidth:   opts.TermWidth,
+		NoHeader:    opts.NoHeader,
 		SortBy:      opts.SortBy,
 		SortDesc:    opts.SortDesc,
-	})
+		SortMissing: opts.SortMissing,
+		GroupBy:     opts.GroupBy,
+		SparkDays:   opts.SparkDays,
+		Concurrency: opts.Concurrency,
+		// Fetch retries and progress
+		FetchRetries: opts.FetchRetries,
+		Progress:     opts.Progress,
+		FetchFailed:  opts.FetchFailed,
+		Notice:       opts.Notice,
+		BatchSize:    opts.BatchSize,
+		// Syms output
+		SymsSep:         opts.SymsSep,
+		SymsStripSuffix: opts.SymsStripSuffix,
+		SymsPerList:     opts.SymsPerList,
+		// Heatmap
+		Heatmap:     opts.Heatmap,
+		HeatmapLow:  opts.HeatmapLow,
+		HeatmapHigh: opts.HeatmapHigh,
+		// Decimal alignment
+		AlignDecimals: opts.AlignDecimals,
+		// JSON snapshot metadata
+		JSONMeta:  opts.JSONMeta,
+		JSONRaw:   opts.JSONRaw,
+		JSONBySym: opts.JSONBySym,
+		// View
+		ColumnLabels: opts.ColumnLabels,
+		ColumnWidths: opts.ColumnWidths,
+		// ASCII-only output
+		ASCII: opts.ASCII,
+		// Per-list item limit
+		Limit: opts.Limit,
+		// Currency conversion
+		ConvertTo: opts.ConvertTo,
+		FXRates:   opts.FXRates,
+		// Movers
+		MoverThreshold: opts.MoverThreshold,
+		OnlyMovers:     opts.OnlyMovers,
+		// Terminal-width fitting
+		AutoFit: opts.AutoFit,
+		// Config color rules
+		ColorRules: opts.ColorRules,
+		// Sideways table
+		Transpose: opts.Transpose,
+		// Table colors
+		Theme: opts.Theme,
+	}
 }
@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/filter"
+	"github.com/komsit37/wl/pkg/wl/render"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// staticSource returns copies of its lists regardless of spec.
+type staticSource []types.Watchlist
+
+func (s staticSource) Load(context.Context, any) ([]types.Watchlist, error) {
+	out := make([]types.Watchlist, len(s))
+	for i, l := range s {
+		out[i] = l
+		out[i].Items = append([]types.Item(nil), l.Items...)
+	}
+	return out, nil
+}
+
+func list(name string, syms ...string) types.Watchlist {
+	l := types.Watchlist{Name: name}
+	for _, s := range syms {
+		l.Items = append(l.Items, types.Item{Sym: s})
+	}
+	return l
+}
+
+func syms(items []types.Item) []string {
+	out := make([]string, len(items))
+	for i, it := range items {
+		out[i] = it.Sym
+	}
+	return out
+}
+
+func TestPrepareMaxSymbols(t *testing.T) {
+	r := &Runner{Source: staticSource{list("a", "AAPL", "MSFT"), list("b", "7203.T")}}
+	_, err := r.Prepare(context.Background(), nil, ExecuteOptions{MaxSymbols: 2})
+	var le *render.LimitError
+	if !errors.As(err, &le) {
+		t.Fatalf("3 symbols over --max-symbols 2: got %v, want a *render.LimitError", err)
+	}
+	if _, err := r.Prepare(context.Background(), nil, ExecuteOptions{MaxSymbols: 3}); err != nil {
+		t.Fatalf("3 symbols at --max-symbols 3: %v", err)
+	}
+	// The limit counts symbols left after the list filter
+	if _, err := r.Prepare(context.Background(), nil, ExecuteOptions{MaxSymbols: 2, Filter: filter.Always(false)}); err != nil {
+		t.Fatalf("filtered out: %v", err)
+	}
+	if _, err := r.Prepare(context.Background(), nil, ExecuteOptions{}); err != nil {
+		t.Fatalf("no limit: %v", err)
+	}
+}
+
+func TestPrepareTags(t *testing.T) {
+	tagged := func(sym string, tags ...any) types.Item {
+		return types.Item{Sym: sym, Fields: map[string]any{"tags": tags}}
+	}
+	r := &Runner{Source: staticSource{
+		{Name: "us", Items: []types.Item{tagged("AAPL", "growth"), tagged("KO", "Dividend"), {Sym: "MSFT"}}},
+		{Name: "jp", Items: []types.Item{tagged("7203.T", "value")}},
+	}}
+	lists, err := r.Prepare(context.Background(), nil, ExecuteOptions{Tags: []string{"DIVIDEND"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 1 || lists[0].Name != "us" || !reflect.DeepEqual(syms(lists[0].Items), []string{"KO"}) {
+		t.Errorf("--tag DIVIDEND kept %+v", lists)
+	}
+	lists, err = r.Prepare(context.Background(), nil, ExecuteOptions{Tags: []string{"dividend"}, KeepEmpty: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 2 || len(lists[1].Items) != 0 {
+		t.Errorf("KeepEmpty: got %+v, want jp kept empty", lists)
+	}
+}
+
+func TestPrepareSelect(t *testing.T) {
+	r := &Runner{Source: staticSource{list("us", "AAPL", "MSFT", "KO"), list("jp", "7203.T", "6758.T"), list("uk", "VOD.L")}}
+	lists, err := r.Prepare(context.Background(), nil, ExecuteOptions{Select: []string{"msft", "aapl", "7203"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 2 || !reflect.DeepEqual(syms(lists[0].Items), []string{"AAPL", "MSFT"}) || !reflect.DeepEqual(syms(lists[1].Items), []string{"7203.T"}) {
+		t.Errorf("--select msft,aapl,7203 kept %+v", lists)
+	}
+	lists, err = r.Prepare(context.Background(), nil, ExecuteOptions{Select: []string{"VOD.L"}, KeepEmpty: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 3 || len(lists[0].Items) != 0 || !reflect.DeepEqual(syms(lists[2].Items), []string{"VOD.L"}) {
+		t.Errorf("--keep-empty kept %+v", lists)
+	}
+}
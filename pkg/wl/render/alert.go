@@ -0,0 +1,131 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// Alert is a numeric threshold on a column, e.g. "chg%<-5" or "pe>=30".
+type Alert struct {
+	Expr   string
+	Column string
+	Op     string
+	Value  float64
+}
+
+// AlertHit is an item whose column value breached an alert.
+type AlertHit struct {
+	Alert   Alert
+	List    string
+	Sym     string
+	Value   float64
+	Display string
+}
+
+var alertRx = regexp.MustCompile(`^\s*(.+?)\s*(<=|>=|==|!=|<|>|=)\s*(\S.*?)\s*$`)
+
+// ParseAlert parses "col<op>value" where op is one of < <= > >= == != (= is ==).
+// The value accepts the same formats as sorting (e.g. "-5", "5%", "1.2B").
+func ParseAlert(expr string) (Alert, error) {
+	m := alertRx.FindStringSubmatch(expr)
+	if m == nil {
+		return Alert{}, fmt.Errorf("invalid alert %q: expected <column><op><number>, e.g. chg%%<-5", expr)
+	}
+	v, ok := parseFormattedNumber(m[3])
+	if !ok {
+		return Alert{}, fmt.Errorf("invalid alert %q: %q is not a number", expr, m[3])
+	}
+	op := m[2]
+	if op == "=" {
+		op = "=="
+	}
+	return Alert{Expr: strings.TrimSpace(expr), Column: m[1], Op: op, Value: v}, nil
+}
+
+// Known reports whether the alert's column names a column (built-in, alias
+// or computed) or a field of an item in lists; a typo would otherwise
+// never fire.
+func (a Alert) Known(lists []types.Watchlist) bool {
+	if _, ok := columns.Canonical(a.Column); ok {
+		return true
+	}
+	for _, l := range lists {
+		for _, it := range l.Items {
+			for k := range it.Fields {
+				if strings.EqualFold(k, a.Column) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Match reports whether v breaches the alert.
+func (a Alert) Match(v float64) bool { return compareOp(a.Op, v, a.Value) }
+
+// compareOp applies op (< <= > >= == !=) to v and threshold.
+func compareOp(op string, v, threshold float64) bool {
+	switch op {
+	case "<":
+		return v < threshold
+	case "<=":
+		return v <= threshold
+	case ">":
+		return v > threshold
+	case ">=":
+		return v >= threshold
+	case "==":
+		return v == threshold
+	case "!=":
+		return v != threshold
+	}
+	return false
+}
+
+// EvaluateAlerts fetches the columns referenced by alerts for every item and
+// returns the breaches. Fetching follows opts like the renderers do
+// (concurrency, retries, --convert-to), so values match the rendered
+// cells; opts.Progress and opts.FetchFailed are not called, the render has
+// reported on the same items. Values use the same numeric extraction as
+// sorting, except that percent columns compare their displayed percentage;
+// items with missing or non-numeric values never trigger.
+func EvaluateAlerts(ctx context.Context, client *yfgo.Client, lists []types.Watchlist, alerts []Alert, opts RenderOptions) []AlertHit {
+	if len(alerts) == 0 {
+		return nil
+	}
+	alertCols := make([]string, 0, len(alerts))
+	for _, a := range alerts {
+		alertCols = append(alertCols, a.Column)
+	}
+	mods := columns.RequiredModules(alertCols)
+	needChart := columns.NeedsModule(alertCols, columns.ModuleChart)
+	opts.Progress, opts.FetchFailed = nil, nil
+	var hits []AlertHit
+	for _, l := range lists {
+		raws := fetchAll(ctx, client, l.Items, mods, needChart, opts)
+		for i, it := range l.Items {
+			m := raws[i]
+			for _, a := range alerts {
+				disp, num, hasNum, missing := computeSortKey(a.Column, it, m)
+				// Percent columns sort by Yahoo's raw fractions; thresholds
+				// are written as shown, so chg%<-5 means below -5%
+				if d := strings.TrimSpace(disp); strings.HasSuffix(d, "%") {
+					num, hasNum = parseFormattedNumber(d)
+				}
+				if missing || !hasNum || !a.Match(num) {
+					continue
+				}
+				hits = append(hits, AlertHit{Alert: a, List: l.Name, Sym: it.Sym, Value: num, Display: strings.TrimSpace(disp)})
+			}
+		}
+	}
+	return hits
+}
@@ -0,0 +1,93 @@
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestParseAlert(t *testing.T) {
+	for expr, want := range map[string]Alert{
+		"chg%<-5":       {Expr: "chg%<-5", Column: "chg%", Op: "<", Value: -5},
+		" pe >= 30 ":    {Expr: "pe >= 30", Column: "pe", Op: ">=", Value: 30},
+		"mktcap>1.2B":   {Expr: "mktcap>1.2B", Column: "mktcap", Op: ">", Value: 1.2e9},
+		"price=100":     {Expr: "price=100", Column: "price", Op: "==", Value: 100},
+		"off_high%!=0%": {Expr: "off_high%!=0%", Column: "off_high%", Op: "!=", Value: 0},
+	} {
+		got, err := ParseAlert(expr)
+		if err != nil || got != want {
+			t.Errorf("ParseAlert(%q) = %+v, %v; want %+v", expr, got, err, want)
+		}
+	}
+	for _, bad := range []string{"chg%", "<5", "pe>cheap"} {
+		if _, err := ParseAlert(bad); err == nil {
+			t.Errorf("ParseAlert(%q): want an error", bad)
+		}
+	}
+}
+
+func TestAlertKnown(t *testing.T) {
+	lists := []types.Watchlist{{Items: []types.Item{{Sym: "AAPL", Fields: map[string]any{"Target": 200}}}}}
+	for col, want := range map[string]bool{"chg%": true, "PE": true, "target": true, "chgpct": false} {
+		if got := (Alert{Column: col}).Known(lists); got != want {
+			t.Errorf("Known(%s) = %v, want %v", col, got, want)
+		}
+	}
+}
+
+func TestEvaluateAlerts(t *testing.T) {
+	client := stubClient(t, map[string]map[string]any{
+		"AAPL": {"price": map[string]any{
+			"regularMarketPrice":         num(180, "%.2f"),
+			"regularMarketChangePercent": map[string]any{"raw": -0.065, "fmt": "-6.50%"},
+		}},
+		"MSFT": {"price": map[string]any{
+			"regularMarketPrice":         num(400, "%.2f"),
+			"regularMarketChangePercent": map[string]any{"raw": 0.012, "fmt": "1.20%"},
+		}},
+	})
+	lists := []types.Watchlist{{Name: "us", Items: []types.Item{{Sym: "AAPL"}, {Sym: "MSFT"}, {Sym: "GONE"}}}}
+	parse := func(exprs ...string) []Alert {
+		var out []Alert
+		for _, e := range exprs {
+			a, err := ParseAlert(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out = append(out, a)
+		}
+		return out
+	}
+
+	hits := EvaluateAlerts(context.Background(), client, lists, parse("chg%<-5", "price>=400"), RenderOptions{})
+	if len(hits) != 2 {
+		t.Fatalf("hits = %+v, want AAPL chg%% and MSFT price", hits)
+	}
+	if h := hits[0]; h.Sym != "AAPL" || h.List != "us" || h.Alert.Column != "chg%" || h.Value != -6.5 || h.Display != "-6.50%" {
+		t.Errorf("chg%% hit = %+v", h)
+	}
+	if h := hits[1]; h.Sym != "MSFT" || h.Value != 400 {
+		t.Errorf("price hit = %+v", h)
+	}
+	// not triggered, and a symbol without data never triggers
+	if hits := EvaluateAlerts(context.Background(), client, lists, parse("chg%<-10", "price<0"), RenderOptions{}); len(hits) != 0 {
+		t.Errorf("untriggered alerts hit: %+v", hits)
+	}
+}
+
+func TestEvaluateAlertsConvert(t *testing.T) {
+	client := stubClient(t, map[string]map[string]any{
+		"7203.T": {"price": map[string]any{"currency": "JPY", "regularMarketPrice": num(2500, "%.2f")}},
+	})
+	lists := []types.Watchlist{{Name: "jp", Items: []types.Item{{Sym: "7203.T"}}}}
+	a, err := ParseAlert("price<20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := RenderOptions{ConvertTo: "USD", FXRates: map[string]float64{"JPYUSD": 0.0067}}
+	hits := EvaluateAlerts(context.Background(), client, lists, []Alert{a}, opts)
+	if len(hits) != 1 || hits[0].Value != 16.75 || hits[0].Display != "$16.75" {
+		t.Errorf("hits = %+v, want the converted price $16.75", hits)
+	}
+}
@@ -0,0 +1,48 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+// asciiRunes maps the Unicode glyphs wl draws to ASCII stand-ins of the same
+// width: sparkline blocks become a rising ramp, box drawing becomes -|+.
+var asciiRunes = map[rune]string{
+	'▁': "_", '▂': ".", '▃': "-", '▄': "~", '▅': "=", '▆': "+", '▇': "*", '█': "#",
+	'·': "-", '—': "-", '–': "-", '…': ".",
+	'─': "-", '━': "-", '│': "|", '┃': "|",
+	'┌': "+", '┐': "+", '└': "+", '┘': "+", '├': "+", '┤': "+", '┬': "+", '┴': "+", '┼': "+",
+	'╭': "+", '╮': "+", '╰': "+", '╯': "+",
+}
+
+// ToASCII replaces every non-ASCII rune in s: known glyphs by their ASCII
+// stand-ins, anything else by one '?' per display cell so columns stay
+// aligned. ANSI color sequences are ASCII and pass through.
+func ToASCII(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r < 0x80 {
+			b.WriteRune(r)
+			continue
+		}
+		if rep, ok := asciiRunes[r]; ok {
+			b.WriteString(rep)
+			continue
+		}
+		b.WriteString(strings.Repeat("?", widthCond.RuneWidth(r)))
+	}
+	return b.String()
+}
+
+// renderASCII runs render into a buffer and writes the result to w through
+// ToASCII.
+func renderASCII(w io.Writer, render func(io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, ToASCII(buf.String()))
+	return err
+}
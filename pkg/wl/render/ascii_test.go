@@ -0,0 +1,66 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// nonASCII returns the first non-ASCII byte's offset in s, or -1.
+func nonASCII(s string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestToASCII(t *testing.T) {
+	if got := ToASCII(columns.Sparkline([]float64{1, 2, 3, 4, 5, 6, 7, 8})); got != "_.-~=+*#" {
+		t.Errorf("sparkline = %q", got)
+	}
+	for in, want := range map[string]string{
+		"├── us":               "+-- us",
+		"│   └── tech":         "|   +-- tech",
+		"トヨタ":                  "??????", // one '?' per display cell
+		"Café — 1…":            "Caf? - 1.",
+		"\x1b[32m+1.2%\x1b[0m": "\x1b[32m+1.2%\x1b[0m",
+	} {
+		if got := ToASCII(in); got != want {
+			t.Errorf("ToASCII(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestASCIIOutput(t *testing.T) {
+	client := stubClient(t, map[string]map[string]any{
+		"7203.T": {"price": map[string]any{"regularMarketPrice": num(2850, "%.2f")}},
+		"MC.PA":  {"price": map[string]any{"regularMarketPrice": num(700, "%.2f")}},
+	})
+	lists := []types.Watchlist{
+		{Name: "jp", Columns: []string{"sym", "name", "price"}, Items: []types.Item{{Sym: "7203.T", Name: "トヨタ自動車"}}},
+		{Name: "eu", Columns: []string{"sym", "name", "price"}, Items: []types.Item{{Sym: "MC.PA", Name: "LVMH Moët Hennessy — Louis Vuitton"}}},
+	}
+	for name, r := range map[string]Renderer{
+		"table":   NewTableRendererWithClient(client),
+		"line":    NewLineRendererWithClient(client),
+		"compact": NewCompactRendererWithClient(client),
+	} {
+		var buf bytes.Buffer
+		if err := r.Render(context.Background(), &buf, lists, RenderOptions{ASCII: true, Color: true}); err != nil {
+			t.Fatal(err)
+		}
+		out := buf.String()
+		if i := nonASCII(out); i >= 0 {
+			t.Errorf("%s: non-ASCII byte at %d in\n%s", name, i, out)
+		}
+		if !strings.Contains(out, "????????????") || !strings.Contains(out, "Mo?t") {
+			t.Errorf("%s: names not replaced:\n%s", name, out)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package render
+
+// autoFitMinWidth is the narrowest a text column is squeezed to by AutoFit.
+const autoFitMinWidth = 8
+
+// cellPadding is the space go-pretty puts around each cell (one on either
+// side) in the borderless, separator-less table style.
+const cellPadding = 2
+
+// autoFitWidths returns per-column WidthMax values that make a table fit
+// termWidth. natural holds each column's widest cell (header included),
+// already capped at maxWidth; only columns with shrink set (text columns
+// without a fixed view width) give up width, the widest first, and none
+// below autoFitMinWidth. A table that cannot fit ends up as narrow as
+// those limits allow.
+func autoFitWidths(natural []int, shrink []bool, termWidth int) []int {
+	widths := append([]int(nil), natural...)
+	total := 0
+	for _, w := range widths {
+		total += w + cellPadding
+	}
+	for total > termWidth {
+		widest := -1
+		for i, w := range widths {
+			if shrink[i] && w > autoFitMinWidth && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
@@ -0,0 +1,138 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// batchQuotes fetches the price data of items through Yahoo's multi-symbol
+// quote endpoint, opts.BatchSize unique symbols per request, and returns the
+// quotes keyed by upper-cased symbol. It only applies when the columns need
+// nothing beyond the price module; otherwise, or when batching is off, it
+// returns nil. Failed chunks and symbols missing from a response are simply
+// absent, so callers fall back to per-symbol quoteSummary fetches for them.
+func batchQuotes(ctx context.Context, client *yfgo.Client, items []types.Item, mods []yfgo.QuoteSummaryModule, opts RenderOptions) map[string]yfgo.Quote {
+	if opts.BatchSize <= 0 || len(mods) != 1 || mods[0] != yfgo.ModulePrice {
+		return nil
+	}
+	seen := map[string]bool{}
+	var syms []string
+	for _, it := range items {
+		s := strings.ToUpper(strings.TrimSpace(it.Sym))
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		syms = append(syms, s)
+	}
+	var chunks [][]string
+	for len(syms) > 0 {
+		n := min(opts.BatchSize, len(syms))
+		chunks = append(chunks, syms[:n])
+		syms = syms[n:]
+	}
+	out := map[string]yfgo.Quote{}
+	var mu sync.Mutex
+	runPool(len(chunks), opts.Concurrency, func(i int) {
+		quotes, err := client.Quote(ctx, chunks[i])
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, q := range quotes {
+			if q.RegularMarketPrice != nil {
+				out[strings.ToUpper(q.Symbol)] = q
+			}
+		}
+	})
+	return out
+}
+
+// quoteToMap shapes a batch quote like the quoteSummary price module so the
+// price columns extract from it unchanged. The endpoint returns raw numbers
+// only; fmt strings are derived the way Yahoo formats them (grouped prices,
+// percentages with two decimals).
+func quoteToMap(q yfgo.Quote) map[string]any {
+	price := map[string]any{}
+	for key, s := range map[string]string{
+		"symbol":       q.Symbol,
+		"shortName":    q.ShortName,
+		"longName":     q.LongName,
+		"currency":     q.Currency,
+		"exchange":     q.Exchange,
+		"exchangeName": q.FullExchangeName,
+		"marketState":  q.MarketState,
+	} {
+		if s != "" { // keep "a|b" path fallbacks working
+			price[key] = s
+		}
+	}
+	num := func(key string, v *float64, format func(float64) string) {
+		if v != nil {
+			price[key] = map[string]any{"raw": *v, "fmt": format(*v)}
+		}
+	}
+	num("regularMarketPrice", q.RegularMarketPrice, formatPrice)
+	num("regularMarketChange", q.RegularMarketChange, formatPrice)
+	num("regularMarketPreviousClose", q.RegularMarketPreviousClose, formatPrice)
+	if q.RegularMarketChangePercent != nil {
+		// quoteSummary reports the change as a fraction, the quote endpoint
+		// as a percentage.
+		pct := *q.RegularMarketChangePercent
+		price["regularMarketChangePercent"] = map[string]any{"raw": pct / 100, "fmt": columns.FormatFloat(pct, 2) + "%"}
+	}
+	if q.RegularMarketTime > 0 {
+		price["regularMarketTime"] = q.RegularMarketTime
+	}
+	if q.MarketCap != nil {
+		price["marketCap"] = map[string]any{"raw": float64(*q.MarketCap), "fmt": formatCompact(float64(*q.MarketCap))}
+	}
+	if q.RegularMarketVolume != nil {
+		price["regularMarketVolume"] = map[string]any{"raw": float64(*q.RegularMarketVolume), "fmt": formatCompact(float64(*q.RegularMarketVolume))}
+	}
+	return map[string]any{"price": price}
+}
+
+// formatPrice prints v with two decimals and comma-grouped thousands, e.g.
+// "1,234.50".
+func formatPrice(v float64) string {
+	s := columns.FormatFloat(v, 2)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String() + "." + frac
+}
+
+// formatCompact abbreviates large numbers with a K/M/B/T suffix, e.g. "2.87T".
+func formatCompact(v float64) string {
+	abs := v
+	if abs < 0 {
+		abs = -abs
+	}
+	for _, u := range []struct {
+		div    float64
+		suffix string
+	}{{1e12, "T"}, {1e9, "B"}, {1e6, "M"}, {1e3, "k"}} {
+		if abs >= u.div {
+			return fmt.Sprintf("%.2f%s", v/u.div, u.suffix)
+		}
+	}
+	return columns.FormatFloat(v, 0)
+}
@@ -0,0 +1,105 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+const benchRows = 1000
+
+// benchCols mixes path, derived and styled columns.
+var benchCols = []string{"sym", "name", "price", "chg%", "mktcap", "pe", "52w_high", "range52", "off_high%", "state"}
+
+func num(raw float64, format string) map[string]any {
+	return map[string]any{"raw": raw, "fmt": fmt.Sprintf(format, raw)}
+}
+
+// benchList returns a list of n symbols and a client that serves their
+// price and summaryDetail modules without touching the network.
+func benchList(b *testing.B, n int) (types.Watchlist, *yfgo.Client) {
+	b.Helper()
+	quotes := map[string]map[string]any{}
+	list := types.Watchlist{Name: "bench", Columns: benchCols}
+	for i := 0; i < n; i++ {
+		sym := fmt.Sprintf("S%04d", i)
+		list.Items = append(list.Items, types.Item{Sym: sym})
+		price := 10 + float64(i%997)
+		quotes[sym] = map[string]any{
+			"price": map[string]any{
+				"shortName":                  "Company " + sym,
+				"currency":                   "USD",
+				"marketState":                "REGULAR",
+				"regularMarketPrice":         num(price, "%.2f"),
+				"regularMarketChangePercent": num(float64(i%21-10)/100, "%.2f%%"),
+				"marketCap":                  num(price*1e9, "%.0f"),
+			},
+			"summaryDetail": map[string]any{
+				"trailingPE":       num(float64(i%40+5), "%.2f"),
+				"fiftyTwoWeekHigh": num(price*1.2, "%.2f"),
+				"fiftyTwoWeekLow":  num(price*0.7, "%.2f"),
+			},
+		}
+	}
+	return list, stubClient(b, quotes)
+}
+
+func BenchmarkTableRender(b *testing.B) {
+	list, client := benchList(b, benchRows)
+	r := NewTableRendererWithClient(client)
+	var out strings.Builder
+	if err := r.Render(context.Background(), &out, []types.Watchlist{list}, RenderOptions{}); err != nil || !strings.Contains(out.String(), "Company S0999") {
+		b.Fatalf("stub render missed rows (err %v)", err)
+	}
+	for _, bc := range []struct{ name, sort string }{
+		{"unsorted", ""},
+		{"sort_chg", "chg%"},
+		{"sort_off_high", "off_high%:asc,sym"},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			opts := RenderOptions{Color: true, SortBy: bc.sort, TermWidth: 200}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := r.Render(context.Background(), io.Discard, []types.Watchlist{list}, opts); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	list, client := benchList(b, benchRows)
+	raws := fetchAll(context.Background(), client, list.Items, []yfgo.QuoteSummaryModule{yfgo.ModulePrice, yfgo.ModuleSummaryDetail}, false, RenderOptions{})
+	if raws[0] == nil {
+		b.Fatal("stub client served no quote")
+	}
+	keys := ParseSortKeys("chg%,mktcap:asc,name", true)
+	type row struct{ sort []sortValue }
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		rows := make([]row, len(list.Items))
+		for ri, it := range list.Items {
+			for _, k := range keys {
+				var v sortValue
+				v.disp, v.num, v.hasNum, v.missing = computeSortKey(k.Col, it, raws[ri])
+				rows[ri].sort = append(rows[ri].sort, v)
+			}
+		}
+		sort.SliceStable(rows, func(x, y int) bool {
+			for k, key := range keys {
+				if c := compareSortValues(rows[x].sort[k], rows[y].sort[k], key.Desc, false); c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+)
+
+// ColorRule colors a table column's cells whose numeric value satisfies a
+// comparison, e.g. pe_ttm above 30 in red. Rules come from color_rules in
+// config and are applied only when color is on.
+type ColorRule struct {
+	Column string // canonical key
+	Op     string
+	Value  float64
+	Color  columns.Color
+}
+
+// ruleColors are the color names a ColorRule accepts.
+var ruleColors = map[string]columns.Color{
+	"red":     columns.ColorRed,
+	"green":   columns.ColorGreen,
+	"yellow":  columns.ColorYellow,
+	"blue":    columns.ColorBlue,
+	"magenta": columns.ColorMagenta,
+	"cyan":    columns.ColorCyan,
+}
+
+// ParseColorRule builds a rule for col from an operator (< <= > >= == !=,
+// = is ==), a threshold in any format sorting understands ("30", "4%",
+// "1.2B") and a color name.
+func ParseColorRule(col, op, value, color string) (ColorRule, error) {
+	key, _ := columns.Canonical(col)
+	if key == "" {
+		return ColorRule{}, fmt.Errorf("color rule: empty column")
+	}
+	op = strings.TrimSpace(op)
+	if op == "=" {
+		op = "=="
+	}
+	switch op {
+	case "<", "<=", ">", ">=", "==", "!=":
+	default:
+		return ColorRule{}, fmt.Errorf("color rule for %s: invalid op %q (want < <= > >= == !=)", key, op)
+	}
+	v, ok := parseFormattedNumber(strings.TrimSpace(value))
+	if !ok {
+		return ColorRule{}, fmt.Errorf("color rule for %s: value %q is not a number", key, value)
+	}
+	c, ok := ruleColors[strings.ToLower(strings.TrimSpace(color))]
+	if !ok {
+		return ColorRule{}, fmt.Errorf("color rule for %s: unknown color %q (want red, green, yellow, blue, magenta or cyan)", key, color)
+	}
+	return ColorRule{Column: key, Op: op, Value: v, Color: c}, nil
+}
+
+// Match reports whether v satisfies the rule.
+func (r ColorRule) Match(v float64) bool { return compareOp(r.Op, v, r.Value) }
+
+// ruleStyle returns the style of the first rule for key matching v.
+func ruleStyle(rules []ColorRule, key string, v float64) (columns.CellStyle, bool) {
+	for _, r := range rules {
+		if r.Column == key && r.Match(v) {
+			return columns.CellStyle{FgColor: r.Color}, true
+		}
+	}
+	return columns.CellStyle{}, false
+}
@@ -0,0 +1,116 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// CompactRenderer prints one block per symbol: the symbol on its own line
+// followed by col=value pairs wrapped to the terminal width. It suits narrow
+// terminals where a table would wrap badly.
+type CompactRenderer struct{ Client *yfgo.Client }
+
+func NewCompactRendererWithClient(client *yfgo.Client) *CompactRenderer {
+	if client == nil {
+		client = yfgo.NewClient()
+	}
+	return &CompactRenderer{Client: client}
+}
+
+func (r *CompactRenderer) Render(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+	if opts.ASCII {
+		opts.ASCII = false
+		return renderASCII(w, func(w io.Writer) error { return r.Render(ctx, w, lists, opts) })
+	}
+	width := opts.TermWidth
+	if width <= 0 {
+		width = 80
+	}
+	multi := len(lists) > 1
+	sep := false // print a blank line before the next block
+	for _, list := range lists {
+		cols := list.Columns
+		mods := columns.RequiredModules(cols)
+		needChart := columns.NeedsModule(cols, columns.ModuleChart)
+		if multi && strings.TrimSpace(list.Name) != "" {
+			if sep {
+				fmt.Fprintln(w)
+			}
+			name := strings.ToUpper(list.Name)
+			if opts.Color {
+				name = text.Bold.Sprint(name)
+			}
+			fmt.Fprintf(w, "== %s ==\n", name)
+			sep = false
+		}
+		items := limitItems(list.Items, opts.Limit)
+		raws := fetchAll(ctx, r.Client, items, mods, needChart, opts)
+		for ri, it := range items {
+			m := raws[ri]
+			if sep {
+				fmt.Fprintln(w)
+			}
+			sep = true
+			sym := it.Sym
+			if opts.Color {
+				sym = text.Bold.Sprint(sym)
+			}
+			if _, err := fmt.Fprintln(w, sym); err != nil {
+				return err
+			}
+			pairs := make([]string, 0, len(cols))
+			for _, c := range cols {
+				key := c
+				if k, ok := columns.Canonical(c); ok {
+					key = k
+				}
+				if key == "sym" {
+					continue
+				}
+				val := strings.TrimSpace(cellValue(key, ri+1, it, m))
+				if val == "" {
+					continue
+				}
+				pairs = append(pairs, columnLabel(opts, c)+"="+val)
+			}
+			for _, line := range wrapPairs(pairs, width, "  ") {
+				if _, err := fmt.Fprintln(w, line); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// wrapPairs joins pairs with two spaces, starting a new indented line
+// whenever the next pair would exceed width.
+func wrapPairs(pairs []string, width int, indent string) []string {
+	var lines []string
+	cur := ""
+	for _, p := range pairs {
+		if cur == "" {
+			cur = indent + p
+			continue
+		}
+		if visibleWidth(cur)+2+visibleWidth(p) > width {
+			lines = append(lines, cur)
+			cur = indent + p
+			continue
+		}
+		cur += "  " + p
+	}
+	if cur != "" {
+		lines = append(lines, cur)
+	}
+	return lines
+}
@@ -0,0 +1,34 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestCompactFetchesLikeTable(t *testing.T) {
+	client := stubClient(t, map[string]map[string]any{
+		"AAPL":   {"price": map[string]any{"currency": "USD", "regularMarketPrice": num(180, "%.2f")}},
+		"7203.T": {"price": map[string]any{"currency": "JPY", "regularMarketPrice": num(2500, "%.2f")}},
+	})
+	list := types.Watchlist{Name: "mixed", Columns: []string{"sym", "price"}, Items: []types.Item{{Sym: "AAPL"}, {Sym: "7203.T"}}}
+	var progress []int
+	opts := RenderOptions{
+		ConvertTo: "USD",
+		FXRates:   map[string]float64{"JPYUSD": 0.0067},
+		Progress:  func(done, _ int) { progress = append(progress, done) },
+	}
+	var buf bytes.Buffer
+	if err := NewCompactRendererWithClient(client).Render(context.Background(), &buf, []types.Watchlist{list}, opts); err != nil {
+		t.Fatal(err)
+	}
+	want := "AAPL\n  price=$180.00\n\n7203.T\n  price=$16.75\n"
+	if got := buf.String(); got != want {
+		t.Errorf("compact output:\n%s\nwant:\n%s", got, want)
+	}
+	if len(progress) != 2 || progress[1] != 2 {
+		t.Errorf("progress = %v, want 1 then 2", progress)
+	}
+}
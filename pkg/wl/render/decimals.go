@@ -0,0 +1,40 @@
+package render
+
+import "strings"
+
+// decimalTail returns the display width after the integer part of a numeric
+// cell: the decimal point, fraction and any suffix ("%", "B", ...). Cells with
+// equal tails line up on the decimal point when right-aligned.
+func decimalTail(s string) int {
+	if i := strings.LastIndex(s, "."); i >= 0 {
+		return visibleWidth(s[i:])
+	}
+	last := strings.LastIndexAny(s, "0123456789")
+	if last < 0 {
+		return 0
+	}
+	return visibleWidth(s[last+1:])
+}
+
+// alignDecimals pads numeric cells of column ci on the right so their decimal
+// points line up. Non-numeric cells are left untouched.
+func alignDecimals(cells [][]string, ci int) {
+	maxTail := 0
+	for _, row := range cells {
+		if _, ok := parseFormattedNumber(row[ci]); ok {
+			if t := decimalTail(row[ci]); t > maxTail {
+				maxTail = t
+			}
+		}
+	}
+	if maxTail == 0 {
+		return
+	}
+	for _, row := range cells {
+		if _, ok := parseFormattedNumber(row[ci]); ok {
+			if pad := maxTail - decimalTail(row[ci]); pad > 0 {
+				row[ci] += strings.Repeat(" ", pad)
+			}
+		}
+	}
+}
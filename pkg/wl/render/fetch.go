@@ -0,0 +1,136 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// fetchAll runs fetchRaw for every item with up to opts.Concurrency fetches
+// in flight, returning the maps in item order. With opts.BatchSize set,
+// price-only columns are first fetched in batches (see batchQuotes) and only
+// the symbols a batch missed go through fetchRaw. opts.Progress hears about
+// each completed item; afterwards opts.FetchFailed hears about each failed
+// one, in item order. With opts.ConvertTo set, currency amounts are
+// converted (see fxConverter).
+func fetchAll(ctx context.Context, client *yfgo.Client, items []types.Item, mods []yfgo.QuoteSummaryModule, needChart bool, opts RenderOptions) []map[string]any {
+	out := make([]map[string]any, len(items))
+	errs := make([]error, len(items))
+	var mu sync.Mutex
+	done := 0
+	fx := newFXConverter(client, opts)
+	mods = fx.withModules(mods)
+	batched := batchQuotes(ctx, client, items, mods, opts)
+	runPool(len(items), opts.Concurrency, func(i int) {
+		if q, ok := batched[strings.ToUpper(strings.TrimSpace(items[i].Sym))]; ok {
+			out[i] = quoteToMap(q)
+			if needChart {
+				out[i] = addCloses(ctx, client, out[i], items[i].Sym, opts)
+			}
+		} else {
+			out[i], errs[i] = fetchRaw(ctx, client, items[i].Sym, mods, needChart, opts)
+		}
+		fx.convert(ctx, out[i])
+		if opts.Progress != nil {
+			mu.Lock()
+			done++
+			opts.Progress(done, len(items))
+			mu.Unlock()
+		}
+	})
+	if opts.FetchFailed != nil {
+		for i, err := range errs {
+			if err != nil {
+				opts.FetchFailed(FetchError{Sym: items[i].Sym, Err: err})
+			}
+		}
+	}
+	return out
+}
+
+// runPool calls fn(i) for each i in [0,n) with at most concurrency calls
+// running at once (DefaultConcurrency when <= 0). The first call runs alone
+// so the Yahoo client sets up its session (cookies and crumb) once rather
+// than in every worker.
+func runPool(n, concurrency int, fn func(i int)) {
+	if n == 0 {
+		return
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	fn(0)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 1; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// fetchRaw loads the quoteSummary modules for sym into an Extract-able map.
+// When needChart is set, recent closes are added under "chart.closes" for
+// chart-backed columns. Transient errors are retried opts.FetchRetries times;
+// remaining errors yield a nil/partial map so a missing symbol only blanks
+// its cells, and are returned when no quote data came back for a non-blank
+// sym. Offline, modules are retried one by one so the cached ones still fill
+// their cells when others are missing.
+func fetchRaw(ctx context.Context, client *yfgo.Client, sym string, mods []yfgo.QuoteSummaryModule, needChart bool, opts RenderOptions) (map[string]any, error) {
+	raw, err := quoteSummary(ctx, client, sym, mods, opts.FetchRetries)
+	if err != nil {
+		raw = nil
+		if errors.Is(err, ErrOffline) && len(mods) > 1 {
+			partial := map[string]any{}
+			for _, mod := range mods {
+				if r, err := client.QuoteSummary(ctx, sym, []yfgo.QuoteSummaryModule{mod}); err == nil {
+					if rm, ok := r.(map[string]any); ok {
+						for k, v := range rm {
+							partial[k] = v
+						}
+					}
+				}
+			}
+			if len(partial) > 0 {
+				raw, err = partial, nil
+			}
+		}
+		if strings.TrimSpace(sym) == "" {
+			err = nil
+		}
+	}
+	m := columns.RawToMap(raw)
+	if needChart {
+		m = addCloses(ctx, client, m, sym, opts)
+	}
+	return m, err
+}
+
+// addCloses adds sym's recent closes to m under "chart.closes", allocating m
+// if needed; m is returned unchanged when no closes could be fetched.
+func addCloses(ctx context.Context, client *yfgo.Client, m map[string]any, sym string, opts RenderOptions) map[string]any {
+	closes := fetchCloses(ctx, client, sym, opts.SparkDays)
+	if len(closes) == 0 {
+		return m
+	}
+	if m == nil {
+		m = map[string]any{}
+	}
+	vals := make([]any, len(closes))
+	for i, c := range closes {
+		vals[i] = c
+	}
+	m["chart"] = map[string]any{"closes": vals}
+	return m
+}
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// FetchError records a symbol whose Yahoo fetch failed.
+type FetchError struct {
+	Sym string
+	Err error
+}
+
+func (e FetchError) Error() string { return e.Sym + ": " + e.Err.Error() }
+
+// Reason condenses the error for a one-line summary: the HTTP status
+// (e.g. "404"), "timeout", "not cached" when offline, "network", or the
+// error text.
+func (e FetchError) Reason() string {
+	err := e.Err
+	switch {
+	case errors.Is(err, ErrOffline):
+		return "not cached"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	if m := yahooStatusRx.FindStringSubmatch(err.Error()); m != nil {
+		return m[1]
+	}
+	var ne net.Error
+	if errors.As(err, &ne) {
+		if ne.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	}
+	msg := err.Error()
+	if len(msg) > 40 {
+		msg = msg[:40] + "..."
+	}
+	return msg
+}
+
+// SummarizeFetchErrors formats failures as "2 symbols failed: FOO (404),
+// BAR (timeout)", listing each symbol once in the order given. It
+// returns "" when errs is empty.
+func SummarizeFetchErrors(errs []FetchError) string {
+	seen := map[string]bool{}
+	parts := make([]string, 0, len(errs))
+	for _, e := range errs {
+		key := strings.ToUpper(e.Sym)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		parts = append(parts, fmt.Sprintf("%s (%s)", e.Sym, e.Reason()))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	noun := "symbols"
+	if len(parts) == 1 {
+		noun = "symbol"
+	}
+	return fmt.Sprintf("%d %s failed: %s", len(parts), noun, strings.Join(parts, ", "))
+}
+
+// LimitError reports input larger than a safety limit allows, e.g. more
+// symbols than --max-symbols or --transpose accept. It is a usage error:
+// narrowing the input or raising the limit fixes it.
+type LimitError struct{ Err error }
+
+func (e *LimitError) Error() string { return e.Err.Error() }
+func (e *LimitError) Unwrap() error { return e.Err }
+
+// RenderError reports that rendering failed or could not finish, e.g. a
+// write error or fetches cut short by a deadline. Its message is the
+// underlying error's.
+type RenderError struct{ Err error }
+
+func (e *RenderError) Error() string { return e.Err.Error() }
+func (e *RenderError) Unwrap() error { return e.Err }
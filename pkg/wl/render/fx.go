@@ -0,0 +1,194 @@
+package render
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+)
+
+// fxExtraFields are amounts derived columns read (tgt_spread%) that no
+// Currency column shows; they convert along with the rest so ratios hold.
+var fxExtraFields = []string{"financialData.targetHighPrice", "financialData.targetLowPrice"}
+
+// currencySymbols prefix converted amounts; other currencies use their code.
+var currencySymbols = map[string]string{
+	"USD": "$", "EUR": "€", "JPY": "¥", "GBP": "£", "CNY": "¥", "INR": "₹", "KRW": "₩",
+}
+
+// minorUnits maps currencies Yahoo quotes in hundredths (pence, cents) to
+// their main currency.
+var minorUnits = map[string]string{"GBp": "GBP", "GBX": "GBP", "ZAc": "ZAR", "ILA": "ILS"}
+
+// fxConverter rewrites the currency amounts of fetched maps into one target
+// currency. Rates come from the static table first (keys like "JPYUSD",
+// one JPY in USD; the inverse pair also works), then from Yahoo's
+// "JPYUSD=X" quote, and are looked up once per converter.
+type fxConverter struct {
+	client *yfgo.Client
+	target string
+	static map[string]float64
+	paths  []string
+
+	mu    sync.Mutex
+	rates map[string]*float64 // by source currency; nil when unavailable
+}
+
+// newFXConverter returns nil when opts.ConvertTo is empty.
+func newFXConverter(client *yfgo.Client, opts RenderOptions) *fxConverter {
+	target := strings.ToUpper(strings.TrimSpace(opts.ConvertTo))
+	if target == "" {
+		return nil
+	}
+	static := map[string]float64{}
+	for k, v := range opts.FXRates {
+		static[strings.ToUpper(strings.TrimSpace(k))] = v
+	}
+	var paths []string
+	for _, p := range append(columns.CurrencyPaths(), fxExtraFields...) {
+		paths = append(paths, strings.TrimSuffix(p, ".fmt"))
+	}
+	return &fxConverter{client: client, target: target, static: static, paths: paths, rates: map[string]*float64{}}
+}
+
+// withModules adds the price module, which carries the quote currency.
+func (c *fxConverter) withModules(mods []yfgo.QuoteSummaryModule) []yfgo.QuoteSummaryModule {
+	if c == nil || len(mods) == 0 || containsMod(mods, yfgo.ModulePrice) {
+		return mods
+	}
+	return append(append([]yfgo.QuoteSummaryModule(nil), mods...), yfgo.ModulePrice)
+}
+
+// convert rewrites m's amounts in place: raw values are scaled so sorting
+// and derived columns see the target currency, and fmt strings show the
+// converted amount with the target's symbol. The rate is stored under
+// columns.FXRatePath for position columns. Without a rate the amounts keep
+// their value and gain a trailing "*".
+func (c *fxConverter) convert(ctx context.Context, m map[string]any) {
+	if c == nil || m == nil {
+		return
+	}
+	from, ok := columns.Extract(m, "price.currency|summaryDetail.currency")
+	if !ok || strings.TrimSpace(from) == "" {
+		return
+	}
+	rate, ok := c.rate(ctx, strings.TrimSpace(from))
+	if ok {
+		m["fx"] = map[string]any{"rate": rate}
+	}
+	for _, p := range c.paths {
+		obj, found := fxField(m, p)
+		if !found {
+			continue
+		}
+		raw, isNum := obj["raw"].(float64)
+		f, _ := obj["fmt"].(string)
+		if !isNum {
+			continue
+		}
+		if !ok {
+			if f != "" {
+				obj["fmt"] = f + "*"
+			}
+			continue
+		}
+		v := raw * rate
+		obj["raw"] = v
+		obj["fmt"] = formatMoney(v, c.target, isCompact(f))
+	}
+}
+
+// rate returns what one unit of from is worth in the target currency.
+func (c *fxConverter) rate(ctx context.Context, from string) (float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, seen := c.rates[from]; seen {
+		return derefRate(r)
+	}
+	var r *float64
+	cur, scale := from, 1.0
+	if major, ok := minorUnits[from]; ok {
+		cur, scale = major, 0.01
+	}
+	cur = strings.ToUpper(cur)
+	if v, ok := c.lookup(ctx, cur); ok {
+		v *= scale
+		r = &v
+	}
+	c.rates[from] = r
+	return derefRate(r)
+}
+
+func (c *fxConverter) lookup(ctx context.Context, cur string) (float64, bool) {
+	if cur == c.target {
+		return 1, true
+	}
+	if v, ok := c.static[cur+c.target]; ok && v > 0 {
+		return v, true
+	}
+	if v, ok := c.static[c.target+cur]; ok && v > 0 {
+		return 1 / v, true
+	}
+	quotes, err := c.client.Quote(ctx, []string{cur + c.target + "=X"})
+	if err != nil || len(quotes) == 0 || quotes[0].RegularMarketPrice == nil || *quotes[0].RegularMarketPrice <= 0 {
+		return 0, false
+	}
+	return *quotes[0].RegularMarketPrice, true
+}
+
+func derefRate(r *float64) (float64, bool) {
+	if r == nil {
+		return 0, false
+	}
+	return *r, true
+}
+
+// fxField returns the {raw, fmt} object at dot path p, if any.
+func fxField(m map[string]any, p string) (map[string]any, bool) {
+	var cur any = m
+	for _, seg := range strings.Split(p, ".") {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = obj[seg]; !ok {
+			return nil, false
+		}
+	}
+	obj, ok := cur.(map[string]any)
+	return obj, ok
+}
+
+// isCompact reports whether Yahoo abbreviated f with a K/M/B/T suffix.
+func isCompact(f string) bool {
+	f = strings.TrimSpace(f)
+	return f != "" && strings.ContainsAny(f[len(f)-1:], "kKMBT")
+}
+
+// formatMoney prints v in currency cur, e.g. "$1,234.50" or "€2.87T".
+func formatMoney(v float64, cur string, compact bool) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	sym, ok := currencySymbols[cur]
+	if !ok {
+		sym = cur + " "
+	}
+	if compact {
+		return sign + sym + formatCompact(v)
+	}
+	return sign + sym + formatPrice(v)
+}
+
+func containsMod(mods []yfgo.QuoteSummaryModule, m yfgo.QuoteSummaryModule) bool {
+	for _, x := range mods {
+		if x == m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+)
+
+// Default heatmap endpoints: lowest value red, highest green, matching the
+// sign coloring used elsewhere (negative red, positive green).
+const (
+	DefaultHeatmapLow  = "#d73027"
+	DefaultHeatmapHigh = "#1a9850"
+)
+
+// RGB is a 24-bit terminal color.
+type RGB struct{ R, G, B uint8 }
+
+// ParseHexColor parses "#rrggbb" or "rrggbb".
+func ParseHexColor(s string) (RGB, error) {
+	h := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(h) != 6 {
+		return RGB{}, fmt.Errorf("invalid color %q: expected #rrggbb", s)
+	}
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid color %q: expected #rrggbb", s)
+	}
+	return RGB{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v)}, nil
+}
+
+// Gradient interpolates linearly between two colors.
+type Gradient struct{ Low, High RGB }
+
+// At returns the color at position t in [0,1]; out-of-range t is clamped.
+func (g Gradient) At(t float64) RGB {
+	if t < 0 {
+		t = 0
+	}
+	if t > 1 {
+		t = 1
+	}
+	lerp := func(a, b uint8) uint8 {
+		return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return RGB{R: lerp(g.Low.R, g.High.R), G: lerp(g.Low.G, g.High.G), B: lerp(g.Low.B, g.High.B)}
+}
+
+// Sprint wraps s in a 24-bit foreground color escape.
+func (c RGB) Sprint(s string) string {
+	return fmt.Sprintf("\x1b[38;2;%d;%d;%dm%s\x1b[0m", c.R, c.G, c.B, s)
+}
+
+// heatmapGradient builds the gradient from options, falling back to defaults.
+func heatmapGradient(opts RenderOptions) (Gradient, error) {
+	lowStr, highStr := opts.HeatmapLow, opts.HeatmapHigh
+	if strings.TrimSpace(lowStr) == "" {
+		lowStr = DefaultHeatmapLow
+	}
+	if strings.TrimSpace(highStr) == "" {
+		highStr = DefaultHeatmapHigh
+	}
+	low, err := ParseHexColor(lowStr)
+	if err != nil {
+		return Gradient{}, err
+	}
+	high, err := ParseHexColor(highStr)
+	if err != nil {
+		return Gradient{}, err
+	}
+	return Gradient{Low: low, High: high}, nil
+}
+
+// heatRange tracks the numeric span of a heatmap column.
+type heatRange struct {
+	min, max float64
+	ok       bool
+}
+
+func (h *heatRange) add(v float64) {
+	if !h.ok {
+		h.min, h.max, h.ok = v, v, true
+		return
+	}
+	if v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+}
+
+// position maps v into [0,1] within the range; a flat range maps to the middle.
+func (h heatRange) position(v float64) float64 {
+	if h.max == h.min {
+		return 0.5
+	}
+	return (v - h.min) / (h.max - h.min)
+}
+
+// heatmapColumns returns the indexes of cols selected by the heatmap option.
+func heatmapColumns(cols []string, heat []string) map[int]bool {
+	if len(heat) == 0 {
+		return nil
+	}
+	want := map[string]bool{}
+	for _, h := range heat {
+		k, _ := columns.Canonical(h)
+		if k != "" {
+			want[k] = true
+		}
+	}
+	out := map[int]bool{}
+	for i, c := range cols {
+		if k, _ := columns.Canonical(c); want[k] {
+			out[i] = true
+		}
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestHeatmapGradient(t *testing.T) {
+	g, err := heatmapGradient(RenderOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r heatRange
+	for _, v := range []float64{-4, 6, 1} {
+		r.add(v)
+	}
+	for _, tc := range []struct {
+		v    float64
+		want RGB
+	}{
+		{-4, RGB{0xd7, 0x30, 0x27}}, // min: DefaultHeatmapLow
+		{1, RGB{0x79, 0x64, 0x3c}},  // mid: halfway between the endpoints
+		{6, RGB{0x1a, 0x98, 0x50}},  // max: DefaultHeatmapHigh
+	} {
+		if got := g.At(r.position(tc.v)); got != tc.want {
+			t.Errorf("color(%v) = %+v, want %+v", tc.v, got, tc.want)
+		}
+	}
+	if got := (heatRange{min: 3, max: 3, ok: true}).position(3); got != 0.5 {
+		t.Errorf("flat range position = %v, want 0.5", got)
+	}
+
+	custom, err := heatmapGradient(RenderOptions{HeatmapLow: "000000", HeatmapHigh: "#ffffff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := custom.At(0.5); got != (RGB{0x80, 0x80, 0x80}) {
+		t.Errorf("custom mid = %+v, want grey", got)
+	}
+	if _, err := heatmapGradient(RenderOptions{HeatmapLow: "red"}); err == nil {
+		t.Error("want an error for a non-hex color")
+	}
+}
+
+func TestTableHeatmap(t *testing.T) {
+	client := stubClient(t, map[string]map[string]any{
+		"AAA": {"price": map[string]any{"regularMarketPrice": num(10, "%.2f")}},
+		"BBB": {"price": map[string]any{"regularMarketPrice": num(20, "%.2f")}},
+		"CCC": {"price": map[string]any{"regularMarketPrice": num(30, "%.2f")}},
+	})
+	list := types.Watchlist{Name: "heat", Columns: []string{"sym", "price"}, Items: []types.Item{{Sym: "AAA"}, {Sym: "BBB"}, {Sym: "CCC"}}}
+	render := func(color bool) string {
+		var buf bytes.Buffer
+		opts := RenderOptions{Color: color, Heatmap: []string{"price"}}
+		if err := NewTableRendererWithClient(client).Render(context.Background(), &buf, []types.Watchlist{list}, opts); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	g, _ := heatmapGradient(RenderOptions{})
+	out := render(true)
+	for v, pos := range map[string]float64{"10.00": 0, "20.00": 0.5, "30.00": 1} {
+		if want := g.At(pos).Sprint(v); !strings.Contains(out, want) {
+			t.Errorf("price %s not colored %q:\n%q", v, want, out)
+		}
+	}
+	if out := render(false); strings.Contains(out, "\x1b[") || !strings.Contains(out, "20.00") {
+		t.Errorf("--no-color output = %q, want plain cells", out)
+	}
+}
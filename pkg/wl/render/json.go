@@ -1,9 +1,16 @@
 package render
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"strings"
+	"time"
 
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
 	"github.com/komsit37/wl/pkg/wl/types"
 )
 
@@ -20,27 +27,144 @@ type jsonItem struct {
 	Fields map[string]any `json:"fields"`
 }
 
-type JSONRenderer struct{}
+// jsonEnvelope is the output shape when RenderOptions.JSONMeta is set.
+type jsonEnvelope struct {
+	GeneratedAt string      `json:"generated_at"`
+	Source      string      `json:"source"`
+	Lists       []jsonModel `json:"lists"`
+}
+
+// jsonBySymEnvelope is the output shape when both RenderOptions.JSONBySym
+// and JSONMeta are set.
+type jsonBySymEnvelope struct {
+	GeneratedAt string                    `json:"generated_at"`
+	Source      string                    `json:"source"`
+	Symbols     map[string]map[string]any `json:"symbols"`
+}
+
+// JSONRenderer writes the lists as JSON. Each item's fields hold its YAML
+// fields plus the displayed value of every selected column, fetched like the
+// table renderer does; with RenderOptions.JSONRaw only the YAML fields are
+// written and nothing is fetched.
+type JSONRenderer struct{ Client *yfgo.Client }
 
-func NewJSONRenderer() *JSONRenderer { return &JSONRenderer{} }
+func NewJSONRenderer() *JSONRenderer { return NewJSONRendererWithClient(nil) }
 
-func (r *JSONRenderer) Render(w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+func NewJSONRendererWithClient(client *yfgo.Client) *JSONRenderer {
+	if client == nil {
+		client = yfgo.NewClient()
+	}
+	return &JSONRenderer{Client: client}
+}
+
+func (r *JSONRenderer) Render(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+	if opts.JSONBySym {
+		return r.renderBySym(ctx, w, lists, opts)
+	}
 	out := make([]jsonModel, 0, len(lists))
 	for _, l := range lists {
 		cols := l.Columns
 		if len(opts.Columns) > 0 {
 			cols = opts.Columns
 		}
-		// Build items; expecting raw values already in Item.Fields
-		items := make([]jsonItem, 0, len(l.Items))
-		for _, it := range l.Items {
-			items = append(items, jsonItem{Sym: it.Sym, Name: it.Name, Fields: it.Fields})
-		}
+		items := jsonItems(ctx, r.Client, limitItems(l.Items, opts.Limit), cols, opts)
 		out = append(out, jsonModel{Name: l.Name, Columns: cols, Items: items})
 	}
 	enc := json.NewEncoder(w)
 	if opts.PrettyJSON {
 		enc.SetIndent("", "  ")
 	}
+	if opts.JSONMeta != nil {
+		return enc.Encode(jsonEnvelope{
+			GeneratedAt: opts.JSONMeta.GeneratedAt.UTC().Format(time.RFC3339),
+			Source:      opts.JSONMeta.Source,
+			Lists:       out,
+		})
+	}
 	return enc.Encode(out)
 }
+
+// renderBySym writes one object mapping each upper-cased symbol to its
+// fields, across all lists. A symbol in several lists gets their fields
+// merged, later lists winning, and is reported through opts.Notice.
+func (r *JSONRenderer) renderBySym(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+	out := map[string]map[string]any{}
+	seenIn := map[string][]string{}
+	var order []string
+	for _, l := range lists {
+		cols := l.Columns
+		if len(opts.Columns) > 0 {
+			cols = opts.Columns
+		}
+		for _, it := range jsonItems(ctx, r.Client, limitItems(l.Items, opts.Limit), cols, opts) {
+			sym := strings.ToUpper(strings.TrimSpace(it.Sym))
+			if sym == "" {
+				continue
+			}
+			fields, ok := out[sym]
+			if !ok {
+				fields = map[string]any{}
+				out[sym] = fields
+				order = append(order, sym)
+			}
+			for k, v := range it.Fields {
+				fields[k] = v
+			}
+			seenIn[sym] = append(seenIn[sym], l.Name)
+		}
+	}
+	if opts.Notice != nil {
+		for _, sym := range order {
+			if in := seenIn[sym]; len(in) > 1 {
+				opts.Notice(fmt.Sprintf("%s is in %d lists (%s); merged, last list wins", sym, len(in), strings.Join(in, ", ")))
+			}
+		}
+	}
+	enc := json.NewEncoder(w)
+	if opts.PrettyJSON {
+		enc.SetIndent("", "  ")
+	}
+	if opts.JSONMeta != nil {
+		return enc.Encode(jsonBySymEnvelope{
+			GeneratedAt: opts.JSONMeta.GeneratedAt.UTC().Format(time.RFC3339),
+			Source:      opts.JSONMeta.Source,
+			Symbols:     out,
+		})
+	}
+	return enc.Encode(out)
+}
+
+// jsonItems builds the JSON items for a list. Unless opts.JSONRaw is set,
+// the modules cols need are fetched and each non-empty column value is
+// stored in Fields under its canonical key, replacing a YAML field of the
+// same name. sym and the row number are left out; they are not fields.
+func jsonItems(ctx context.Context, client *yfgo.Client, items []types.Item, cols []string, opts RenderOptions) []jsonItem {
+	var raws []map[string]any
+	if !opts.JSONRaw && len(cols) > 0 {
+		raws = fetchAll(ctx, client, items, columns.RequiredModules(cols), columns.NeedsModule(cols, columns.ModuleChart), opts)
+	}
+	out := make([]jsonItem, 0, len(items))
+	for i, it := range items {
+		fields := it.Fields
+		if raws != nil {
+			fields = make(map[string]any, len(it.Fields)+len(cols))
+			for k, v := range it.Fields {
+				fields[k] = v
+			}
+			for _, c := range cols {
+				key := c
+				if k, ok := columns.Canonical(c); ok {
+					key = k
+				}
+				if key == "sym" || key == columns.RowNumberKey {
+					continue
+				}
+				if v := strings.TrimSpace(renderFromRaw(key, it, raws[i])); v != "" {
+					fields[key] = v
+				}
+			}
+		}
+		out = append(out, jsonItem{Sym: it.Sym, Name: columns.ItemName(it), Fields: fields})
+	}
+	return out
+}
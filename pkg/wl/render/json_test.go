@@ -0,0 +1,40 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestJSONMeta(t *testing.T) {
+	lists := []types.Watchlist{{Name: "us", Items: []types.Item{{Sym: "AAPL", Fields: map[string]any{"sym": "AAPL"}}}}}
+	render := func(meta *JSONMeta) map[string]any {
+		var buf bytes.Buffer
+		opts := RenderOptions{JSONRaw: true, JSONMeta: meta}
+		if err := NewJSONRendererWithClient(stubClient(t, nil)).Render(context.Background(), &buf, lists, opts); err != nil {
+			t.Fatal(err)
+		}
+		var out any
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatalf("%v: %s", err, buf.String())
+		}
+		m, _ := out.(map[string]any)
+		return m
+	}
+
+	at := time.Date(2026, 10, 16, 9, 30, 0, 0, time.FixedZone("JST", 9*3600))
+	got := render(&JSONMeta{GeneratedAt: at, Source: "/home/me/.wl/watchlist"})
+	if got["generated_at"] != "2026-10-16T00:30:00Z" || got["source"] != "/home/me/.wl/watchlist" {
+		t.Errorf("meta = %v, %v", got["generated_at"], got["source"])
+	}
+	if l, _ := got["lists"].([]any); len(l) != 1 {
+		t.Errorf("lists = %v", got["lists"])
+	}
+	if got := render(nil); got != nil {
+		t.Errorf("without JSONMeta the output is the bare list array, got %v", got)
+	}
+}
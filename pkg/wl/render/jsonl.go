@@ -0,0 +1,56 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// jsonlItem is one JSONL line: a jsonItem tagged with its watchlist name.
+type jsonlItem struct {
+	List string `json:"list"`
+	jsonItem
+}
+
+// JSONLRenderer writes one compact JSON object per item (JSON Lines), for
+// streaming into log pipelines, with the same fields as JSONRenderer. Each
+// line is flushed as it is written when w supports Flush.
+type JSONLRenderer struct{ Client *yfgo.Client }
+
+func NewJSONLRenderer() *JSONLRenderer { return NewJSONLRendererWithClient(nil) }
+
+func NewJSONLRendererWithClient(client *yfgo.Client) *JSONLRenderer {
+	if client == nil {
+		client = yfgo.NewClient()
+	}
+	return &JSONLRenderer{Client: client}
+}
+
+func (r *JSONLRenderer) Render(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+	enc := json.NewEncoder(w)
+	flusher, _ := w.(interface{ Flush() error })
+	for _, l := range lists {
+		cols := l.Columns
+		if len(opts.Columns) > 0 {
+			cols = opts.Columns
+		}
+		for _, item := range jsonItems(ctx, r.Client, limitItems(l.Items, opts.Limit), cols, opts) {
+			if item.Fields == nil {
+				item.Fields = map[string]any{}
+			}
+			if err := enc.Encode(jsonlItem{List: l.Name, jsonItem: item}); err != nil {
+				return err
+			}
+			if flusher != nil {
+				if err := flusher.Flush(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// DefaultLineColumns is used by the line renderer when no columns are selected.
+var DefaultLineColumns = []string{"sym", "price", "chg%"}
+
+// LineRenderer prints one line per item, e.g. "AAPL  $180.00  +1.2%", with
+// values padded into columns and colored by each column's Style.
+type LineRenderer struct{ Client *yfgo.Client }
+
+func NewLineRendererWithClient(client *yfgo.Client) *LineRenderer {
+	if client == nil {
+		client = yfgo.NewClient()
+	}
+	return &LineRenderer{Client: client}
+}
+
+func (r *LineRenderer) Render(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+	if opts.ASCII {
+		opts.ASCII = false
+		return renderASCII(w, func(w io.Writer) error { return r.Render(ctx, w, lists, opts) })
+	}
+	const gap = "  "
+	multi := len(lists) > 1
+	for li, list := range lists {
+		cols := list.Columns
+		mods := columns.RequiredModules(cols)
+		needChart := columns.NeedsModule(cols, columns.ModuleChart)
+
+		// First pass: resolve values and column widths.
+		type lineRow struct {
+			it   types.Item
+			raw  map[string]any
+			vals []string
+		}
+		items := limitItems(list.Items, opts.Limit)
+		rows := make([]lineRow, 0, len(items))
+		widths := make([]int, len(cols))
+		raws := fetchAll(ctx, r.Client, items, mods, needChart, opts)
+		for ri, it := range items {
+			m := raws[ri]
+			vals := make([]string, len(cols))
+			for ci, c := range cols {
+				key := c
+				if k, ok := columns.Canonical(c); ok {
+					key = k
+				}
+				vals[ci] = strings.TrimSpace(cellValue(key, ri+1, it, m))
+				if vw := visibleWidth(vals[ci]); vw > widths[ci] {
+					widths[ci] = vw
+				}
+			}
+			rows = append(rows, lineRow{it: it, raw: m, vals: vals})
+		}
+
+		if multi {
+			if li > 0 {
+				fmt.Fprintln(w)
+			}
+			name := strings.ToUpper(list.Name)
+			if opts.Color {
+				name = text.Bold.Sprint(name)
+			}
+			fmt.Fprintln(w, name)
+		}
+
+		// Second pass: pad (numbers right, text left) and color.
+		for _, row := range rows {
+			parts := make([]string, len(cols))
+			for ci, c := range cols {
+				val := row.vals[ci]
+				pad := strings.Repeat(" ", widths[ci]-visibleWidth(val))
+				key := c
+				if k, ok := columns.Canonical(c); ok {
+					key = k
+				}
+				cell := val
+				if opts.Color {
+					cell = styledValue(key, val, row.it, row.raw)
+				}
+				if _, ok := parseFormattedNumber(val); ok && key != "sym" {
+					parts[ci] = pad + cell
+				} else {
+					parts[ci] = cell + pad
+				}
+			}
+			line := strings.TrimRight(strings.Join(parts, gap), " ")
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// styledValue applies the column's Style (if any) to val, returning val
+// unchanged when the column has no style.
+func styledValue(key, val string, it types.Item, m map[string]any) string {
+	def, ok := columns.GetDef(key)
+	if !ok || def.Style == nil {
+		return val
+	}
+	var numPtr *float64
+	if f, ok := parseFormattedNumber(val); ok {
+		numPtr = &f
+	}
+	st := def.Style(columns.CellContext{Key: key, Item: it, Raw: m, Display: val, Numeric: numPtr})
+	if styled := styleWithTextColors(val, st); styled != nil {
+		return fmt.Sprint(styled)
+	}
+	return val
+}
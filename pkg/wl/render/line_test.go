@@ -0,0 +1,62 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func lineFixture(t *testing.T) (*LineRenderer, types.Watchlist) {
+	t.Helper()
+	client := stubClient(t, map[string]map[string]any{
+		"AAPL": {"price": map[string]any{
+			"currency":                   "USD",
+			"regularMarketPrice":         num(180, "%.2f"),
+			"regularMarketChangePercent": map[string]any{"raw": 0.012, "fmt": "1.20%"},
+		}},
+		"F": {"price": map[string]any{
+			"currency":                   "USD",
+			"regularMarketPrice":         num(9.5, "%.2f"),
+			"regularMarketChangePercent": map[string]any{"raw": -0.008, "fmt": "-0.80%"},
+		}},
+	})
+	list := types.Watchlist{Name: "us", Columns: DefaultLineColumns, Items: []types.Item{{Sym: "AAPL"}, {Sym: "F"}}}
+	return NewLineRendererWithClient(client), list
+}
+
+func TestLineRender(t *testing.T) {
+	r, list := lineFixture(t)
+	var buf bytes.Buffer
+	if err := r.Render(context.Background(), &buf, []types.Watchlist{list}, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	want := "AAPL  180.00   1.20%\n" +
+		"F       9.50  -0.80%\n"
+	if got := buf.String(); got != want {
+		t.Errorf("line output:\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestLineRenderColorsBySign(t *testing.T) {
+	r, list := lineFixture(t)
+	var buf bytes.Buffer
+	if err := r.Render(context.Background(), &buf, []types.Watchlist{list, {Name: "empty", Columns: DefaultLineColumns}}, RenderOptions{Color: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		text.Bold.Sprint("US") + "\n",
+		text.FgGreen.Sprint("1.20%"),
+		text.FgRed.Sprint("-0.80%"),
+		"\n\n" + text.Bold.Sprint("EMPTY") + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("colored output missing %q:\n%q", want, out)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package render
+
+import (
+	"math"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+)
+
+// moverPath locates the day's change as a fraction (0.012 is 1.2%).
+const moverPath = "price.regularMarketChangePercent.raw"
+
+// isMover reports whether the day's change in m moved more than threshold
+// percent either way. Rows without change data are not movers.
+func isMover(m map[string]any, threshold float64) bool {
+	v, ok := columns.RawFloats(m, moverPath)
+	if !ok {
+		return false
+	}
+	return math.Abs(v[0]*100) > threshold
+}
@@ -0,0 +1,64 @@
+package render
+
+import (
+	"context"
+	"crypto/sha1" // #nosec G505 -- matches yf-go's cache file naming
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	yfgo "github.com/komsit37/yf-go"
+)
+
+// ErrOffline is returned for any Yahoo request the offline client would have
+// sent over the network.
+var ErrOffline = errors.New("offline: not in cache")
+
+// NewOfflineClient returns a Yahoo client that answers only from the yf-go
+// file cache in dir and never touches the network. Entries are served
+// regardless of age and the cache is never written or pruned, so going
+// offline keeps stale data around instead of expiring it.
+func NewOfflineClient(dir string) *yfgo.Client {
+	return yfgo.NewClient(
+		yfgo.WithHTTPClient(&http.Client{Transport: offlineTransport{}}),
+		yfgo.WithCacheStore(offlineStore{dir: dir}),
+	)
+}
+
+// offlineTransport fails every request with ErrOffline.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, ErrOffline
+}
+
+// offlineStore reads yf-go file cache records without expiring them; writes
+// and deletes are ignored.
+type offlineStore struct{ dir string }
+
+func (s offlineStore) Get(_ context.Context, key string) (yfgo.CacheEntry, bool, error) {
+	sum := sha1.Sum([]byte(key)) // #nosec G401 -- cache key, not security
+	data, err := os.ReadFile(filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return yfgo.CacheEntry{}, false, nil
+	}
+	if err != nil {
+		return yfgo.CacheEntry{}, false, err
+	}
+	var rec struct {
+		Payload  []byte    `json:"payload"`
+		StoredAt time.Time `json:"storedAt"`
+	}
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return yfgo.CacheEntry{}, false, nil
+	}
+	return yfgo.CacheEntry{Payload: rec.Payload, StoredAt: rec.StoredAt}, true, nil
+}
+
+func (offlineStore) Set(context.Context, string, yfgo.CacheEntry) error { return nil }
+
+func (offlineStore) Delete(context.Context, string) error { return nil }
@@ -1,14 +1,19 @@
 package render
 
 import (
+	"context"
 	"io"
+	"time"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
 
 	"github.com/komsit37/wl/pkg/wl/types"
 )
 
-// Renderer renders watchlists to an output writer.
+// Renderer renders watchlists to an output writer. Renderers that fetch
+// quotes stop fetching once ctx is done and render what they have.
 type Renderer interface {
-	Render(w io.Writer, lists []types.Watchlist, opts RenderOptions) error
+	Render(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error
 }
 
 type RenderOptions struct {
@@ -17,7 +22,118 @@ type RenderOptions struct {
 	PrettyJSON  bool
 	MaxColWidth int
 	TermWidth   int
-	// Sorting
+	NoHeader    bool
+	// AlignDecimals pads numeric columns so decimal points line up.
+	AlignDecimals bool
+	// Syms output
+	SymsSep         string // separator between symbols; empty means ","
+	SymsStripSuffix string // suffix removed from each symbol (e.g. ".T"); empty keeps symbols as-is
+	SymsPerList     bool   // print one line per list prefixed by its name
+	// Sorting: SortBy is comma-separated keys, each optionally suffixed
+	// ":asc" or ":desc"; SortDesc is the default direction.
 	SortBy   string
 	SortDesc bool
+	// SortMissing places rows without a sort value "first" or "last" (default).
+	SortMissing string
+	// SparkDays is the number of daily closes drawn by the spark column.
+	SparkDays int
+	// Concurrency caps how many symbols are fetched at once; <= 0 uses
+	// DefaultConcurrency.
+	Concurrency int
+	// BatchSize, when > 0, fetches price-only columns through Yahoo's
+	// multi-symbol quote endpoint this many symbols per request (table, line
+	// and compact output), falling back to per-symbol fetches for any it misses.
+	BatchSize int
+	// Progress, when set, is called by the table, line and compact renderers (and
+	// WarmCache) as each item of a list has been fetched, with done out of the list's total;
+	// done == total means the list's rows are about to be written.
+	Progress func(done, total int)
+	// FetchFailed, when set, is called for each item whose quote data
+	// could not be fetched (its row renders blank), in list order.
+	FetchFailed func(FetchError)
+	// Notice, when set, hears non-fatal notes about the output, such as
+	// symbols merged by JSONBySym.
+	Notice func(string)
+	// FetchRetries is how many times a transient Yahoo error (network,
+	// 429, 5xx) is retried per symbol, with exponential backoff.
+	FetchRetries int
+	// GroupBy groups rows by a column's display value, inserting a header row
+	// per group; SortBy then applies within each group.
+	GroupBy string
+	// Heatmap colors the listed columns on a gradient from HeatmapLow
+	// (lowest value) to HeatmapHigh (highest); colors are "#rrggbb".
+	Heatmap     []string
+	HeatmapLow  string
+	HeatmapHigh string
+	// JSONMeta, when set, wraps JSON output in an object carrying when and
+	// where the snapshot was generated.
+	JSONMeta *JSONMeta
+	// JSONRaw limits JSON and JSONL items to their YAML fields, skipping
+	// the fetch of column values.
+	JSONRaw bool
+	// JSONBySym makes JSON output one object mapping each symbol to its
+	// fields across all lists, instead of the list array.
+	JSONBySym bool
+	// ColumnLabels and ColumnWidths, keyed by canonical column key, replace
+	// a column's header text and fix its table width (from --view).
+	ColumnLabels map[string]string
+	ColumnWidths map[string]int
+	// Limit keeps at most this many items per list, after sorting in table
+	// output and in file order elsewhere; 0 means no limit.
+	Limit int
+	// ASCII restricts table, line and compact output to ASCII: borders and
+	// sparklines use ASCII stand-ins and other characters become '?'.
+	ASCII bool
+	// ConvertTo, when set (e.g. "USD"), converts currency columns of table,
+	// line and compact output into that currency; FXRates holds static
+	// rates keyed by pair, e.g. "JPYUSD": 0.0067, used before Yahoo's.
+	ConvertTo string
+	FXRates   map[string]float64
+	// MoverThreshold, when > 0, leaves table rows whose chg% moved at most
+	// this many percent either way (or is missing) uncolored; OnlyMovers
+	// drops those rows instead.
+	MoverThreshold float64
+	OnlyMovers     bool
+	// AutoFit narrows the widest text columns of table output so rows fit
+	// TermWidth; with TermWidth 0 it does nothing and MaxColWidth applies.
+	AutoFit bool
+	// ColorRules color table cells whose value meets a threshold (from
+	// color_rules in config); the first matching rule for a column wins.
+	ColorRules []ColorRule
+	// Transpose lays table output out sideways: a row per column and a
+	// column per symbol, for at most TransposeMaxSymbols symbols per list.
+	Transpose bool
+	// Theme names the color scheme of table and summary output (see
+	// ThemeNames); empty uses DefaultTheme.
+	Theme string
+}
+
+// columnLabel returns the display label for column c.
+func columnLabel(opts RenderOptions, c string) string {
+	if l, ok := opts.ColumnLabels[canonicalCol(c)]; ok {
+		return l
+	}
+	return c
+}
+
+// limitItems returns the first n items, or all of them when n <= 0.
+func limitItems(items []types.Item, n int) []types.Item {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
+
+// canonicalCol resolves aliases, keeping unknown columns (YAML fields) as-is.
+func canonicalCol(c string) string {
+	if k, ok := columns.Canonical(c); ok {
+		return k
+	}
+	return c
+}
+
+// JSONMeta is the snapshot metadata written by JSONRenderer.
+type JSONMeta struct {
+	GeneratedAt time.Time
+	Source      string
 }
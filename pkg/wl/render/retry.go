@@ -0,0 +1,61 @@
+package render
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	yfgo "github.com/komsit37/yf-go"
+)
+
+// retryBaseDelay is the wait before the first retry; it doubles each time.
+const retryBaseDelay = 500 * time.Millisecond
+
+// yahooStatusRx finds the HTTP status in yf-go errors such as
+// "yahoo finance error: 429 Too Many Requests: ...".
+var yahooStatusRx = regexp.MustCompile(`(?:error|failed): (\d{3}) `)
+
+// quoteSummary calls client.QuoteSummary, retrying transient failures up to
+// retries times with exponential backoff and jitter. It gives up early when
+// the next wait would pass ctx's deadline; the last error is returned.
+func quoteSummary(ctx context.Context, client *yfgo.Client, sym string, mods []yfgo.QuoteSummaryModule, retries int) (any, error) {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		raw, err := client.QuoteSummary(ctx, sym, mods)
+		if err == nil || attempt >= retries || !isTransient(err) {
+			return raw, err
+		}
+		// Wait delay ± 50%.
+		wait := delay/2 + time.Duration(rand.Int63n(int64(delay)))
+		if dl, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(dl) {
+			return raw, err
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return raw, err
+		case <-t.C:
+		}
+		delay *= 2
+	}
+}
+
+// isTransient reports whether a fetch error may succeed on retry: network
+// failures and 429/5xx responses. Offline misses, cancellation and other
+// statuses (e.g. 404 for an unknown symbol) are permanent.
+func isTransient(err error) bool {
+	if err == nil || errors.Is(err, ErrOffline) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if m := yahooStatusRx.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return code == 429 || code >= 500
+	}
+	var ne net.Error
+	return errors.As(err, &ne)
+}
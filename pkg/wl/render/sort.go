@@ -0,0 +1,155 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortKey is one column of a multi-column sort.
+type SortKey struct {
+	Col  string
+	Desc bool
+}
+
+// IsFileOrder reports whether spec is the "file" (or "none") sentinel that
+// renders items in their original order.
+func IsFileOrder(spec string) bool {
+	switch strings.ToLower(strings.TrimSpace(spec)) {
+	case "file", "none":
+		return true
+	}
+	return false
+}
+
+// ParseSortKeys splits a comma-separated sort spec such as
+// "sector,mktcap:desc". Keys without an ":asc"/":desc" suffix use desc.
+func ParseSortKeys(spec string, desc bool) []SortKey {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k := SortKey{Col: part, Desc: desc}
+		if i := strings.LastIndex(part, ":"); i > 0 {
+			switch strings.ToLower(strings.TrimSpace(part[i+1:])) {
+			case "desc":
+				k = SortKey{Col: strings.TrimSpace(part[:i]), Desc: true}
+			case "asc":
+				k = SortKey{Col: strings.TrimSpace(part[:i]), Desc: false}
+			}
+		}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Sort placements for missing values.
+const (
+	SortMissingLast  = "last"
+	SortMissingFirst = "first"
+)
+
+// ParseSortMissing validates a --sort-missing value; empty means last.
+func ParseSortMissing(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", SortMissingLast:
+		return SortMissingLast, nil
+	case SortMissingFirst:
+		return SortMissingFirst, nil
+	default:
+		return "", fmt.Errorf("invalid sort-missing %q: expected first or last", s)
+	}
+}
+
+// sortValue is a row's value for one sort key, as derived by computeSortKey.
+type sortValue struct {
+	disp    string
+	num     float64
+	hasNum  bool
+	missing bool
+}
+
+// compareSortValues orders a and b for one key, returning <0, 0 or >0.
+// Missing values sort last (or first with missingFirst) regardless of
+// direction; numbers compare
+// numerically when both sides have one, otherwise text compares in natural
+// order (see naturalCompare). Ties fall back to the exact display text.
+func compareSortValues(a, b sortValue, desc, missingFirst bool) int {
+	missing := 1
+	if missingFirst {
+		missing = -1
+	}
+	switch {
+	case a.missing && b.missing:
+		return 0
+	case a.missing:
+		return missing
+	case b.missing:
+		return -missing
+	}
+	c := 0
+	if a.hasNum && b.hasNum {
+		switch {
+		case a.num < b.num:
+			c = -1
+		case a.num > b.num:
+			c = 1
+		}
+	} else {
+		c = naturalCompare(a.disp, b.disp)
+	}
+	if c == 0 {
+		c = strings.Compare(a.disp, b.disp)
+	}
+	if desc {
+		c = -c
+	}
+	return c
+}
+
+// naturalCompare compares strings case-insensitively in natural order: runs
+// of digits compare by numeric value, so "A2" sorts before "A10".
+func naturalCompare(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	for a != "" && b != "" {
+		ac, arest := nextChunk(a)
+		bc, brest := nextChunk(b)
+		if isDigit(ac[0]) && isDigit(bc[0]) {
+			// Compare digit runs by value: fewer significant digits is smaller.
+			an, bn := strings.TrimLeft(ac, "0"), strings.TrimLeft(bc, "0")
+			if len(an) != len(bn) {
+				if len(an) < len(bn) {
+					return -1
+				}
+				return 1
+			}
+			if c := strings.Compare(an, bn); c != 0 {
+				return c
+			}
+		} else if c := strings.Compare(ac, bc); c != 0 {
+			return c
+		}
+		a, b = arest, brest
+	}
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return -1
+	default:
+		return 1
+	}
+}
+
+// nextChunk splits off the leading run of digits or non-digits of s.
+func nextChunk(s string) (string, string) {
+	digit := isDigit(s[0])
+	i := 1
+	for i < len(s) && isDigit(s[i]) == digit {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
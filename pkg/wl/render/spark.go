@@ -0,0 +1,53 @@
+package render
+
+import (
+	"context"
+
+	yfgo "github.com/komsit37/yf-go"
+)
+
+// DefaultSparkDays is the number of daily closes drawn by the spark column.
+const DefaultSparkDays = 20
+
+// chartRangeForDays picks the smallest Yahoo chart range that covers n trading
+// days. Fixed ranges (rather than explicit periods) keep chart requests cacheable.
+func chartRangeForDays(n int) string {
+	switch {
+	case n <= 5:
+		return "5d"
+	case n <= 20:
+		return "1mo"
+	case n <= 60:
+		return "3mo"
+	case n <= 120:
+		return "6mo"
+	case n <= 250:
+		return "1y"
+	case n <= 500:
+		return "2y"
+	default:
+		return "5y"
+	}
+}
+
+// fetchCloses returns up to the last n daily closes for sym, skipping gaps.
+// Errors yield nil so a missing history only blanks the cell.
+func fetchCloses(ctx context.Context, client *yfgo.Client, sym string, n int) []float64 {
+	if n <= 0 {
+		n = DefaultSparkDays
+	}
+	res, err := client.ChartTyped(ctx, sym, yfgo.ChartOptions{Interval: "1d", Range: chartRangeForDays(n)})
+	if err != nil || len(res.Indicators.Quote) == 0 {
+		return nil
+	}
+	closes := make([]float64, 0, len(res.Indicators.Quote[0].Close))
+	for _, c := range res.Indicators.Quote[0].Close {
+		if c != nil {
+			closes = append(closes, *c)
+		}
+	}
+	if len(closes) > n {
+		closes = closes[len(closes)-n:]
+	}
+	return closes
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// summaryColumns are the columns SummaryRenderer rolls up; their modules are
+// the only ones it fetches.
+var summaryColumns = []string{"mktcap", "pe_ttm", "div_yield%"}
+
+// SummaryRenderer prints one row per watchlist instead of one per item:
+// the symbol count, total market cap, and average trailing PE and dividend
+// yield over the items that have a value. The selected columns are ignored.
+type SummaryRenderer struct{ Client *yfgo.Client }
+
+func NewSummaryRendererWithClient(client *yfgo.Client) *SummaryRenderer {
+	if client == nil {
+		client = yfgo.NewClient()
+	}
+	return &SummaryRenderer{Client: client}
+}
+
+func (r *SummaryRenderer) Render(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+	if len(lists) == 0 {
+		return nil
+	}
+	if opts.ASCII {
+		opts.ASCII = false
+		return renderASCII(w, func(w io.Writer) error { return r.Render(ctx, w, lists, opts) })
+	}
+	tw := table.NewWriter()
+	tw.SetStyle(themeStyle(opts.Theme))
+	if !opts.Color {
+		tw.Style().Color = table.ColorOptions{}
+	}
+	tw.Style().Options.DrawBorder = false
+	tw.Style().Options.SeparateRows = false
+	tw.Style().Options.SeparateColumns = false
+	if !opts.NoHeader {
+		tw.AppendHeader(table.Row{"LIST", "COUNT", "MKTCAP", "AVG PE", "AVG YIELD"})
+	}
+	cfgs := []table.ColumnConfig{{Number: 1, Align: text.AlignLeft}}
+	for n := 2; n <= 5; n++ {
+		cfgs = append(cfgs, table.ColumnConfig{Number: n, Align: text.AlignRight, AlignHeader: text.AlignRight})
+	}
+	tw.SetColumnConfigs(cfgs)
+
+	mods := columns.RequiredModules(summaryColumns)
+	for _, list := range lists {
+		items := list.Items
+		raws := fetchAll(ctx, r.Client, items, mods, false, opts)
+		var mcap, pe, yield rollup
+		for i, it := range items {
+			mcap.add(renderFromRaw("mktcap", it, raws[i]))
+			pe.add(renderFromRaw("pe_ttm", it, raws[i]))
+			yield.add(renderFromRaw("div_yield%", it, raws[i]))
+		}
+		row := table.Row{list.Name, strconv.Itoa(len(items)), "", "", ""}
+		if mcap.n > 0 {
+			if opts.ConvertTo != "" {
+				row[2] = formatMoney(mcap.sum, strings.ToUpper(opts.ConvertTo), true)
+			} else {
+				row[2] = formatCompact(mcap.sum)
+			}
+		}
+		if pe.n > 0 {
+			row[3] = columns.FormatFloat(pe.avg(), 2)
+		}
+		if yield.n > 0 {
+			row[4] = columns.FormatPercent(yield.avg(), 2)
+		}
+		tw.AppendRow(row)
+	}
+	_, err := io.WriteString(w, strings.TrimRight(tw.Render(), "\n")+"\n")
+	return err
+}
+
+// rollup accumulates the displayed values parseFormattedNumber understands;
+// blank or non-numeric cells are skipped.
+type rollup struct {
+	sum float64
+	n   int
+}
+
+func (r *rollup) add(disp string) {
+	if f, ok := parseFormattedNumber(strings.TrimSpace(disp)); ok {
+		r.sum += f
+		r.n++
+	}
+}
+
+func (r rollup) avg() float64 { return r.sum / float64(r.n) }
@@ -1,6 +1,7 @@
 package render
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -8,24 +9,50 @@ import (
 	"github.com/komsit37/wl/pkg/wl/types"
 )
 
-// symsRenderer prints all symbols in a single comma-separated line.
+// symsRenderer prints symbols as a separated line, either flattened across
+// all lists or one line per list.
 type symsRenderer struct{}
 
 func NewSymsRenderer() Renderer {
 	return symsRenderer{}
 }
 
-func (symsRenderer) Render(w io.Writer, lists []types.Watchlist, _ RenderOptions) error {
-	symbols := make([]string, 0)
-	for _, list := range lists {
-		for _, item := range list.Items {
-			sym := strings.TrimSpace(item.Sym)
-			if sym == "" {
-				continue
+func (symsRenderer) Render(_ context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+	sep := opts.SymsSep
+	if sep == "" {
+		sep = ","
+	}
+	if opts.SymsPerList {
+		for _, list := range lists {
+			list.Items = limitItems(list.Items, opts.Limit)
+			symbols := listSymbols(list, opts.SymsStripSuffix)
+			if _, err := fmt.Fprintf(w, "%s: %s\n", list.Name, strings.Join(symbols, sep)); err != nil {
+				return err
 			}
-			symbols = append(symbols, strings.TrimSuffix(sym, ".T"))
 		}
+		return nil
+	}
+	symbols := make([]string, 0)
+	for _, list := range lists {
+		list.Items = limitItems(list.Items, opts.Limit)
+		symbols = append(symbols, listSymbols(list, opts.SymsStripSuffix)...)
 	}
-	_, err := fmt.Fprintln(w, strings.Join(symbols, ","))
+	_, err := fmt.Fprintln(w, strings.Join(symbols, sep))
 	return err
 }
+
+// listSymbols returns the non-empty symbols of a list with suffix trimmed.
+func listSymbols(list types.Watchlist, suffix string) []string {
+	symbols := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		sym := strings.TrimSpace(item.Sym)
+		if sym == "" {
+			continue
+		}
+		if suffix != "" {
+			sym = strings.TrimSuffix(sym, suffix)
+		}
+		symbols = append(symbols, sym)
+	}
+	return symbols
+}
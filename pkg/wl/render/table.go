@@ -8,10 +8,11 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
-	"github.com/mattn/go-runewidth"
 
 	yfgo "github.com/komsit37/yf-go"
 
@@ -30,10 +31,35 @@ func NewTableRendererWithClient(client *yfgo.Client) *TableRenderer {
 	return &TableRenderer{Client: client}
 }
 
-func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
+func (r *TableRenderer) Render(ctx context.Context, w io.Writer, lists []types.Watchlist, opts RenderOptions) error {
 	if len(lists) == 0 {
 		return nil
 	}
+	if opts.ASCII {
+		opts.ASCII = false
+		return renderASCII(w, func(w io.Writer) error { return r.Render(ctx, w, lists, opts) })
+	}
+	// Fail before fetching anything when a list cannot be transposed;
+	// --only-movers may still shrink a list, so those are checked per list
+	if opts.Transpose && !opts.OnlyMovers {
+		for _, list := range lists {
+			n := len(list.Items)
+			if opts.Limit > 0 && n > opts.Limit {
+				n = opts.Limit
+			}
+			if n > TransposeMaxSymbols {
+				return transposeLimitError(list.Name, n)
+			}
+		}
+	}
+	var gradient Gradient
+	if opts.Color && len(opts.Heatmap) > 0 {
+		g, err := heatmapGradient(opts)
+		if err != nil {
+			return err
+		}
+		gradient = g
+	}
 	const columnGap = 4
 	gapStr := strings.Repeat(" ", columnGap)
 	multi := len(lists) > 1
@@ -49,95 +75,131 @@ func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts Render
 
 		var nameLine string
 		if multi && strings.TrimSpace(list.Name) != "" {
-			nameLine = text.Bold.Sprint(strings.ToUpper(list.Name))
+			nameLine = strings.ToUpper(list.Name)
+			if opts.Color {
+				nameLine = text.Bold.Sprint(nameLine)
+			}
 		}
 
 		tw := table.NewWriter()
-		tw.SetStyle(table.StyleColoredDark)
+		tw.SetStyle(themeStyle(opts.Theme))
+		if !opts.Color {
+			tw.Style().Color = table.ColorOptions{}
+		}
 		tw.Style().Options.DrawBorder = false
 		tw.Style().Options.SeparateRows = false
 		tw.Style().Options.SeparateColumns = false
 
-		// Column header row
-		hdr := make(table.Row, len(cols))
-		for i, c := range cols {
-			hdr[i] = strings.ToUpper(c)
+		// Column header row (column configs below still apply without it)
+		if !opts.NoHeader {
+			hdr := make(table.Row, len(cols))
+			for i, c := range cols {
+				hdr[i] = strings.ToUpper(columnLabel(opts, c))
+			}
+			tw.AppendHeader(hdr)
 		}
-		tw.AppendHeader(hdr)
 
 		// We'll compute dynamic per-column alignment after gathering row values.
 		// Then we set the ColumnConfigs before appending rows.
 
 		// Pre-fetch and compute sort keys
 		type rowData struct {
-			it       types.Item
-			raw      map[string]any
-			dispSort string
-			numSort  float64
-			hasNum   bool
-			missing  bool
+			it    types.Item
+			idx   int // position in list.Items, for file order
+			raw   map[string]any
+			sort  []sortValue
+			group string
 		}
 
 		rows := make([]rowData, 0, len(list.Items))
 		// Determine modules needed for display columns plus possibly sort column
 		neededCols := cols
-		if strings.TrimSpace(opts.SortBy) != "" {
-			// ensure sort column is included for module calc
-			neededCols = append(append([]string(nil), cols...), opts.SortBy)
+		fileOrder := IsFileOrder(opts.SortBy)
+		var sortKeys []SortKey
+		if !fileOrder {
+			sortKeys = ParseSortKeys(opts.SortBy, opts.SortDesc)
+		}
+		if len(sortKeys) > 0 {
+			// ensure sort columns are included for module calc
+			neededCols = append([]string(nil), neededCols...)
+			for _, k := range sortKeys {
+				neededCols = append(neededCols, k.Col)
+			}
+		}
+		groupBy := strings.TrimSpace(opts.GroupBy)
+		if groupBy != "" {
+			neededCols = append(append([]string(nil), neededCols...), groupBy)
+		}
+		if opts.MoverThreshold > 0 || opts.OnlyMovers {
+			neededCols = append(append([]string(nil), neededCols...), "chg%")
 		}
 		mods := columns.RequiredModules(neededCols)
-		for _, it := range list.Items {
-			raw, err := r.Client.QuoteSummary(context.Background(), it.Sym, mods)
-			if err != nil {
-				raw = nil
+		needChart := columns.NeedsModule(neededCols, columns.ModuleChart)
+		items := list.Items
+		needWeights := hasColumn(neededCols, "weight%")
+		if !fileOrder && len(sortKeys) == 0 && !opts.OnlyMovers && !needWeights {
+			// Unsorted: rows past the limit need not be fetched at all
+			items = limitItems(items, opts.Limit)
+		}
+		raws := fetchAll(ctx, r.Client, items, mods, needChart, opts)
+		if needWeights {
+			// Weights need every row's market value before any row renders
+			addWeights(items, raws)
+		}
+		for idx, it := range items {
+			m := raws[idx]
+			if opts.OnlyMovers && !isMover(m, opts.MoverThreshold) {
+				continue
 			}
-			m := columns.RawToMap(raw)
-			rd := rowData{it: it, raw: m}
-			if strings.TrimSpace(opts.SortBy) != "" {
-				rd.dispSort, rd.numSort, rd.hasNum, rd.missing = computeSortKey(opts.SortBy, it, m)
+			rd := rowData{it: it, idx: idx, raw: m}
+			for _, k := range sortKeys {
+				var v sortValue
+				v.disp, v.num, v.hasNum, v.missing = computeSortKey(k.Col, it, m)
+				rd.sort = append(rd.sort, v)
+			}
+			if groupBy != "" {
+				key := groupBy
+				if k, ok := columns.Canonical(groupBy); ok {
+					key = k
+				}
+				rd.group = strings.TrimSpace(renderFromRaw(key, it, m))
 			}
 			rows = append(rows, rd)
 		}
 
-		// Sort if requested
-		if strings.TrimSpace(opts.SortBy) != "" {
+		// Sort if requested: keys compare in order, each with its own direction.
+		// File order restores the original item order explicitly.
+		if fileOrder {
 			sort.SliceStable(rows, func(i, j int) bool {
-				a, b := rows[i], rows[j]
-				// Missing values sort last
-				if a.missing && b.missing {
-					return false
-				}
-				if a.missing {
-					return false
-				}
-				if b.missing {
-					return true
-				}
-				// Numeric compare when both numeric
-				if a.hasNum && b.hasNum {
-					if opts.SortDesc {
-						if a.numSort == b.numSort {
-							return strings.Compare(a.dispSort, b.dispSort) > 0
-						}
-						return a.numSort > b.numSort
-					}
-					if a.numSort == b.numSort {
-						return strings.Compare(a.dispSort, b.dispSort) < 0
-					}
-					return a.numSort < b.numSort
-				}
-				// Fallback to case-insensitive lexicographic compare
-				ad, bd := strings.ToLower(a.dispSort), strings.ToLower(b.dispSort)
 				if opts.SortDesc {
-					if ad == bd {
-						return a.dispSort > b.dispSort
+					return rows[i].idx > rows[j].idx
+				}
+				return rows[i].idx < rows[j].idx
+			})
+		} else if len(sortKeys) > 0 {
+			missingFirst := opts.SortMissing == SortMissingFirst
+			sort.SliceStable(rows, func(i, j int) bool {
+				for k, key := range sortKeys {
+					if c := compareSortValues(rows[i].sort[k], rows[j].sort[k], key.Desc, missingFirst); c != 0 {
+						return c < 0
 					}
-					return ad > bd
 				}
-				if ad == bd {
-					return a.dispSort < b.dispSort
+				return false
+			})
+		}
+		if opts.Limit > 0 && len(rows) > opts.Limit {
+			rows = rows[:opts.Limit]
+		}
+
+		// Group rows: stable so any --sort order is kept within each group.
+		// Empty group values collect under "(unknown)" at the end.
+		if groupBy != "" {
+			sort.SliceStable(rows, func(i, j int) bool {
+				a, b := rows[i].group, rows[j].group
+				if a == "" || b == "" {
+					return a != "" && b == ""
 				}
-				return ad < bd
+				return strings.ToLower(a) < strings.ToLower(b)
 			})
 		}
 
@@ -157,9 +219,11 @@ func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts Render
 				}
 				// If column has a custom renderer, use it; else default renderFromRaw.
 				var val string
-				if def, ok := columns.GetDef(key); ok && def.Render != nil {
-					ctx := columns.CellContext{Key: key, Item: it, Raw: m}
-					val = strings.TrimSpace(def.Render(ctx))
+				if key == columns.RowNumberKey {
+					val = strconv.Itoa(ri + 1)
+				} else if def, ok := columns.GetDef(key); ok && def.Render != nil {
+					cellCtx := columns.CellContext{Key: key, Item: it, Raw: m}
+					val = strings.TrimSpace(def.Render(cellCtx))
 				} else {
 					val = strings.TrimSpace(renderFromRaw(key, it, m))
 				}
@@ -175,14 +239,67 @@ func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts Render
 			cells[ri] = line
 		}
 
+		// Decimal alignment: pad numeric columns (right-aligned by the stats
+		// heuristic below) so their decimal points line up.
+		if opts.AlignDecimals {
+			for ci := range cols {
+				if stats[ci].nums > 0 && stats[ci].nums >= stats[ci].texts {
+					alignDecimals(cells, ci)
+				}
+			}
+		}
+
+		// Heatmap: second pass over the cells to find each column's numeric span.
+		var heatCols map[int]bool
+		heatRanges := make([]heatRange, len(cols))
+		if opts.Color {
+			heatCols = heatmapColumns(cols, opts.Heatmap)
+			for ci := range heatCols {
+				for ri := range cells {
+					if f, ok := parseFormattedNumber(cells[ri][ci]); ok {
+						heatRanges[ci].add(f)
+					}
+				}
+			}
+		}
+
 		// Column configs: wrap text to MaxColWidth (default 40), no truncation.
 		maxWidth := opts.MaxColWidth
 		if maxWidth <= 0 {
 			maxWidth = 40
 		}
+		// AutoFit: shrink the widest text columns until the table fits
+		// the terminal; numbers and view widths are never squeezed.
+		var fitted []int
+		if opts.AutoFit && opts.TermWidth > 0 {
+			natural := make([]int, len(cols))
+			shrink := make([]bool, len(cols))
+			for i, c := range cols {
+				if !opts.NoHeader {
+					natural[i] = visibleWidth(strings.ToUpper(columnLabel(opts, c)))
+				}
+				for ri := range cells {
+					if w := visibleWidth(cells[ri][i]); w > natural[i] {
+						natural[i] = w
+					}
+				}
+				if natural[i] > maxWidth {
+					natural[i] = maxWidth
+				}
+				_, fixed := opts.ColumnWidths[canonicalCol(c)]
+				shrink[i] = !fixed && stats[i].texts > stats[i].nums
+			}
+			fitted = autoFitWidths(natural, shrink, opts.TermWidth)
+		}
 		cfgs := make([]table.ColumnConfig, 0, len(cols))
 		for i := range cols {
 			cfg := table.ColumnConfig{Number: i + 1, WidthMax: maxWidth}
+			if fitted != nil {
+				cfg.WidthMax = fitted[i]
+			}
+			if w, ok := opts.ColumnWidths[canonicalCol(cols[i])]; ok {
+				cfg.WidthMin, cfg.WidthMax = w, w
+			}
 			// Respect explicit per-column alignment if provided in ColumnDef
 			if def, ok := columns.GetDef(cols[i]); ok {
 				switch def.Align {
@@ -227,8 +344,35 @@ func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts Render
 			tw.SetColumnConfigs(cfgs)
 		}
 
-		// Render rows, applying color where applicable.
+		// Count rows per group for the group header rows.
+		groupCounts := map[string]int{}
+		for _, rdata := range rows {
+			groupCounts[rdata.group]++
+		}
+
+		if opts.Transpose && len(rows) > TransposeMaxSymbols {
+			return transposeLimitError(list.Name, len(rows))
+		}
+
+		// Render rows, applying color where applicable. Transposed tables
+		// collect the styled rows and lay them out sideways afterwards.
+		var styled []table.Row
 		for ri, rdata := range rows {
+			if groupBy != "" && !opts.Transpose && (ri == 0 || rows[ri-1].group != rdata.group) {
+				label := rdata.group
+				if label == "" {
+					label = "(unknown)"
+				}
+				label = fmt.Sprintf("%s (%d)", label, groupCounts[rdata.group])
+				if opts.Color {
+					label = text.Bold.Sprint(label)
+				}
+				grp := make(table.Row, len(cols))
+				for ci := range grp {
+					grp[ci] = label
+				}
+				tw.AppendRow(grp, table.RowConfig{AutoMerge: true, AutoMergeAlign: text.AlignLeft})
+			}
 			m := rdata.raw
 			row := make(table.Row, len(cols))
 			for ci, c := range cols {
@@ -238,15 +382,33 @@ func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts Render
 				}
 				val := cells[ri][ci]
 				cell := any(val)
-				// Apply per-column Style if defined
-				if opts.Color {
+				// Heatmap columns take precedence over per-column Style
+				if heatCols[ci] {
+					if f, ok := parseFormattedNumber(val); ok && heatRanges[ci].ok {
+						cell = gradient.At(heatRanges[ci].position(f)).Sprint(val)
+					}
+					row[ci] = cell
+					continue
+				}
+				// Config color rules take precedence over per-column Style
+				if opts.Color && len(opts.ColorRules) > 0 {
+					if f, ok := parseFormattedNumber(val); ok {
+						if st, ok := ruleStyle(opts.ColorRules, key, f); ok {
+							row[ci] = styleWithTextColors(val, st)
+							continue
+						}
+					}
+				}
+				// Apply per-column Style if defined; rows that moved less
+				// than MoverThreshold stay uncolored
+				if opts.Color && (opts.MoverThreshold <= 0 || isMover(m, opts.MoverThreshold)) {
 					if def, ok := columns.GetDef(key); ok && def.Style != nil {
 						var numPtr *float64
 						if f, ok := parseFormattedNumber(val); ok {
 							numPtr = &f
 						}
-						ctx := columns.CellContext{Key: key, Item: rdata.it, Raw: m, Display: val, Numeric: numPtr}
-						st := def.Style(ctx)
+						cellCtx := columns.CellContext{Key: key, Item: rdata.it, Raw: m, Display: val, Numeric: numPtr}
+						st := def.Style(cellCtx)
 						if styled := styleWithTextColors(val, st); styled != nil {
 							cell = styled
 						}
@@ -254,8 +416,19 @@ func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts Render
 				}
 				row[ci] = cell
 			}
+			if opts.Transpose {
+				styled = append(styled, row)
+				continue
+			}
 			tw.AppendRow(row)
 		}
+		if opts.Transpose {
+			syms := make([]string, len(rows))
+			for ri, rdata := range rows {
+				syms[ri] = rdata.it.Sym
+			}
+			tw = transposeTable(cols, syms, styled, opts, maxWidth)
+		}
 
 		rendered := strings.TrimRight(tw.Render(), "\n")
 		lines := make([]string, 0, len(cols)+len(rows)+1)
@@ -339,6 +512,52 @@ func (r *TableRenderer) Render(w io.Writer, lists []types.Watchlist, opts Render
 	return nil
 }
 
+// TransposeMaxSymbols caps the symbols per list --transpose lays out as
+// columns; more would recreate the wide table it is meant to avoid.
+const TransposeMaxSymbols = 10
+
+func transposeLimitError(list string, n int) error {
+	return &LimitError{Err: fmt.Errorf("--transpose shows at most %d symbols per list; %q has %d (narrow it with --select or --limit)", TransposeMaxSymbols, list, n)}
+}
+
+// transposeTable lays a list out sideways for --transpose: one row per
+// selected column, labelled in the first cell, and one column per symbol,
+// with the symbols as the header. cells holds the styled rows in symbol
+// order; the sym and row-number columns are dropped as redundant.
+func transposeTable(cols, syms []string, cells []table.Row, opts RenderOptions, maxWidth int) table.Writer {
+	tw := table.NewWriter()
+	tw.SetStyle(themeStyle(opts.Theme))
+	if !opts.Color {
+		tw.Style().Color = table.ColorOptions{}
+	}
+	tw.Style().Options.DrawBorder = false
+	tw.Style().Options.SeparateRows = false
+	tw.Style().Options.SeparateColumns = false
+	if !opts.NoHeader {
+		hdr := table.Row{""}
+		for _, s := range syms {
+			hdr = append(hdr, s)
+		}
+		tw.AppendHeader(hdr)
+	}
+	cfgs := []table.ColumnConfig{{Number: 1, Colors: tw.Style().Color.Header}}
+	for i := range syms {
+		cfgs = append(cfgs, table.ColumnConfig{Number: i + 2, WidthMax: maxWidth})
+	}
+	tw.SetColumnConfigs(cfgs)
+	for ci, c := range cols {
+		if key := canonicalCol(c); key == "sym" || key == columns.RowNumberKey {
+			continue
+		}
+		row := table.Row{strings.ToUpper(columnLabel(opts, c))}
+		for _, r := range cells {
+			row = append(row, r[ci])
+		}
+		tw.AppendRow(row)
+	}
+	return tw
+}
+
 var ansiColorRx = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
 func visibleWidth(s string) int {
@@ -346,7 +565,7 @@ func visibleWidth(s string) int {
 		return 0
 	}
 	clean := ansiColorRx.ReplaceAllString(s, "")
-	return runewidth.StringWidth(clean)
+	return widthCond.StringWidth(clean)
 }
 
 // styleWithTextColors maps columns.CellStyle to go-pretty text.Colors and returns a formatted Sprintf wrapper.
@@ -380,12 +599,27 @@ func styleWithTextColors(val string, st columns.CellStyle) any {
 	case columns.ColorCyan:
 		colors = append(colors, text.BgCyan)
 	}
+	if st.Bold {
+		colors = append(colors, text.Bold)
+	}
+	if st.Faint {
+		colors = append(colors, text.Faint)
+	}
 	if len(colors) == 0 {
 		return nil
 	}
 	return text.Colors(colors).Sprintf("%v", val)
 }
 
+// cellValue is renderFromRaw for a displayed cell at 1-based row within its
+// list, filling in the synthetic row number column.
+func cellValue(key string, row int, it types.Item, m map[string]any) string {
+	if key == columns.RowNumberKey {
+		return strconv.Itoa(row)
+	}
+	return renderFromRaw(key, it, m)
+}
+
 // renderFromRaw extracts a value for a canonical key from raw map, with fallbacks.
 func renderFromRaw(key string, it types.Item, m map[string]any) string {
 	if def, ok := columns.GetDef(key); ok && def.Render != nil {
@@ -396,8 +630,8 @@ func renderFromRaw(key string, it types.Item, m map[string]any) string {
 	case "sym":
 		return it.Sym
 	case "name":
-		if it.Name != "" {
-			return it.Name
+		if n := columns.ItemName(it); n != "" {
+			return n
 		}
 		if v, ok := columns.Extract(m, "price.shortName|price.longName"); ok {
 			return v
@@ -426,6 +660,46 @@ func renderFromRaw(key string, it types.Item, m map[string]any) string {
 	}
 }
 
+// addWeights is the first pass of the weight% column: it sums the items'
+// market values, skipping items without one, and stores each item's share
+// of the total (in percent) under columns.WeightPath in a copy of its raw
+// map.
+func addWeights(items []types.Item, raws []map[string]any) {
+	values := make([]float64, len(items))
+	has := make([]bool, len(items))
+	var total float64
+	for i, it := range items {
+		if v, ok := columns.NumericValue("mkt_value", it, raws[i]); ok {
+			values[i], has[i] = v, true
+			total += v
+		}
+	}
+	if total == 0 {
+		return
+	}
+	for i := range items {
+		if !has[i] {
+			continue
+		}
+		m := make(map[string]any, len(raws[i])+1)
+		for k, v := range raws[i] {
+			m[k] = v
+		}
+		m["portfolio"] = map[string]any{"weight": values[i] / total * 100}
+		raws[i] = m
+	}
+}
+
+// hasColumn reports whether cols names key, directly or by alias.
+func hasColumn(cols []string, key string) bool {
+	for _, c := range cols {
+		if k, ok := columns.Canonical(c); ok && k == key {
+			return true
+		}
+	}
+	return false
+}
+
 // computeSortKey derives display string and best-effort numeric value for sorting.
 // It handles known YF-backed columns (preferring raw values), YAML custom fields,
 // formatted strings (currency, K/M/B/T), and percentages like chg%.
@@ -442,9 +716,31 @@ func computeSortKey(col string, it types.Item, m map[string]any) (disp string, n
 	}
 
 	// Try to extract numeric raw for known keys
-	// Special-case chg%
-	if key == "chg%" {
-		if v, ok := columns.Extract(m, "price.regularMarketChangePercent.raw"); ok {
+	if key == "as_of" {
+		if v, ok := columns.Extract(m, columns.AsOfPath); ok {
+			if f, err := parseFloatStrict(v); err == nil {
+				return disp, f, true, false
+			}
+		}
+	}
+	if def, ok := columns.GetDef(key); ok && def.Value != nil {
+		if f, ok := def.Value(columns.CellContext{Key: key, Item: it, Raw: m}); ok {
+			return disp, f, true, false
+		}
+	}
+	// Change columns (chg%, pre_chg%, post_chg%) sort by their raw value
+	if strings.HasSuffix(key, "chg%") {
+		if def, ok := columns.GetDef(key); ok && def.Path != "" {
+			if v, ok := columns.Extract(m, strings.ReplaceAll(def.Path, ".fmt", ".raw")); ok {
+				if f, err := parseFloatStrict(v); err == nil {
+					return disp, f, true, false
+				}
+			}
+		}
+	}
+	// Date columns render their .raw epoch path; sort by the timestamp
+	if def, ok := columns.GetDef(key); ok && def.Render != nil && strings.HasSuffix(def.Path, ".raw") {
+		if v, ok := columns.Extract(m, def.Path); ok {
 			if f, err := parseFloatStrict(v); err == nil {
 				return disp, f, true, false
 			}
@@ -461,7 +757,7 @@ func computeSortKey(col string, it types.Item, m map[string]any) (disp string, n
 	}
 
 	// Fallback: parse formatted text (currency, percent, K/M/B/T)
-	if f, ok := parseFormattedNumber(d); ok {
+	if f, ok := parseSortNumber(d); ok {
 		return disp, f, true, false
 	}
 
@@ -475,7 +771,7 @@ func computeSortKey(col string, it types.Item, m map[string]any) (disp string, n
 					if f, err := parseFloatStrict(s); err == nil {
 						return disp, f, true, false
 					}
-					if f, ok := parseFormattedNumber(s); ok {
+					if f, ok := parseSortNumber(s); ok {
 						return disp, f, true, false
 					}
 				}
@@ -488,6 +784,16 @@ func computeSortKey(col string, it types.Item, m map[string]any) (disp string, n
 	return disp, 0, false, false
 }
 
+// parseSortNumber is parseFormattedNumber for sorting: text starting with a
+// letter (symbols like A10, names) is not numeric, so it sorts in natural
+// order rather than by its embedded digits.
+func parseSortNumber(s string) (float64, bool) {
+	if r, _ := utf8.DecodeRuneInString(s); unicode.IsLetter(r) {
+		return 0, false
+	}
+	return parseFormattedNumber(s)
+}
+
 // parseFloatStrict tries to parse a plain float string.
 func parseFloatStrict(s string) (float64, error) {
 	return strconv.ParseFloat(strings.TrimSpace(s), 64)
@@ -0,0 +1,248 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// stubStore is a yf-go cache store preloaded with quoteSummary modules;
+// writes and deletes are ignored so every read hits.
+type stubStore map[string][]byte
+
+func (s stubStore) Get(_ context.Context, key string) (yfgo.CacheEntry, bool, error) {
+	p, ok := s[key]
+	return yfgo.CacheEntry{Payload: p, StoredAt: time.Now()}, ok, nil
+}
+
+func (stubStore) Set(context.Context, string, yfgo.CacheEntry) error { return nil }
+func (stubStore) Delete(context.Context, string) error               { return nil }
+
+// stubClient returns a Yahoo client serving quotes (symbol -> module name
+// -> module data) from memory; anything else fails as offline.
+func stubClient(tb testing.TB, quotes map[string]map[string]any) *yfgo.Client {
+	tb.Helper()
+	store := stubStore{}
+	for sym, mods := range quotes {
+		for mod, v := range mods {
+			p, err := json.Marshal(v)
+			if err != nil {
+				tb.Fatal(err)
+			}
+			store["quotesummary-module:"+sym+":"+mod] = p
+		}
+	}
+	return yfgo.NewClient(
+		yfgo.WithHTTPClient(&http.Client{Transport: offlineTransport{}}),
+		yfgo.WithCacheStore(store),
+	)
+}
+
+// quote builds a raw map holding a price and, optionally, more modules.
+func quote(price float64) map[string]any {
+	return map[string]any{
+		"price": map[string]any{
+			"regularMarketPrice": map[string]any{"raw": price, "fmt": ""},
+		},
+	}
+}
+
+func TestHoldingColumns(t *testing.T) {
+	held := types.Item{Sym: "AAPL", Fields: map[string]any{"cost": 100, "shares": "10"}}
+	m := quote(150)
+	m["defaultKeyStatistics"] = map[string]any{"sharesOutstanding": map[string]any{"raw": 1.5e10, "fmt": "15B"}}
+	for key, want := range map[string]string{
+		"mkt_value": "1500.00",
+		"pnl":       "500.00",
+		"pnl%":      "50.0%",
+		"shares":    "15B", // Yahoo's shares outstanding; pnl reads the item's field
+	} {
+		if got := renderFromRaw(key, held, m); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	_, num, hasNum, _ := computeSortKey("pnl", held, m)
+	if !hasNum || num != 500 {
+		t.Errorf("pnl sort key = %v (%v), want 500", num, hasNum)
+	}
+
+	noCost := types.Item{Sym: "MSFT", Fields: map[string]any{"shares": 5}}
+	for _, key := range []string{"mkt_value", "pnl", "pnl%"} {
+		if got := renderFromRaw(key, noCost, quote(300)); got != "" {
+			t.Errorf("%s without cost = %q, want blank", key, got)
+		}
+		if got := renderFromRaw(key, held, nil); got != "" {
+			t.Errorf("%s without price = %q, want blank", key, got)
+		}
+	}
+}
+
+func TestHoldingColumnsConverted(t *testing.T) {
+	held := types.Item{Sym: "7203.T", Fields: map[string]any{"cost": 2000, "shares": 100}}
+	m := quote(2500)
+	m["price"].(map[string]any)["currency"] = "JPY"
+	fx := newFXConverter(nil, RenderOptions{ConvertTo: "USD", FXRates: map[string]float64{"JPYUSD": 0.0067}})
+	fx.convert(context.Background(), m)
+	for key, want := range map[string]string{
+		"mkt_value": "1675.00",
+		"pnl":       "335.00", // cost converts with the price
+		"pnl%":      "25.0%",
+	} {
+		if got := renderFromRaw(key, held, m); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// recordingStore is a stubStore that records the keys read. Fetch workers
+// call Get concurrently.
+type recordingStore struct {
+	stubStore
+	mu   sync.Mutex
+	keys []string
+}
+
+func (s *recordingStore) Get(ctx context.Context, key string) (yfgo.CacheEntry, bool, error) {
+	s.mu.Lock()
+	s.keys = append(s.keys, key)
+	s.mu.Unlock()
+	return s.stubStore.Get(ctx, key)
+}
+
+func TestQuickColumnsFetchOnlyPrice(t *testing.T) {
+	store := &recordingStore{stubStore: stubStore{}}
+	client := yfgo.NewClient(
+		yfgo.WithHTTPClient(&http.Client{Transport: offlineTransport{}}),
+		yfgo.WithCacheStore(store),
+	)
+	list := types.Watchlist{Name: "q", Columns: columns.QuickColumns, Items: []types.Item{{Sym: "AAPL"}, {Sym: "MSFT"}}}
+	if err := NewTableRendererWithClient(client).Render(context.Background(), io.Discard, []types.Watchlist{list}, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.keys) == 0 {
+		t.Fatal("nothing fetched")
+	}
+	for _, k := range store.keys {
+		if !strings.HasSuffix(k, ":price") {
+			t.Errorf("--quick columns read %s, want only the price module", k)
+		}
+	}
+}
+
+func TestYAMLNameWinsOverFetched(t *testing.T) {
+	fetched := func(name string) map[string]any {
+		return map[string]any{"price": map[string]any{"shortName": name, "longName": name + " Common Stock"}}
+	}
+	client := stubClient(t, map[string]map[string]any{
+		"AAPL": fetched("Apple Inc."),
+		"MSFT": fetched("Microsoft Corporation"),
+		"KO":   fetched("Coca-Cola Company"),
+	})
+	list := types.Watchlist{Name: "us", Columns: []string{"sym", "name"}, Items: []types.Item{
+		{Sym: "AAPL", Name: "Apple"},
+		{Sym: "MSFT", Fields: map[string]any{"Name": "Microsoft"}},
+		{Sym: "KO"},
+	}}
+	want := []string{"Apple", "Microsoft", "Coca-Cola Company"}
+	lists := []types.Watchlist{list}
+
+	var table bytes.Buffer
+	if err := NewTableRendererWithClient(client).Render(context.Background(), &table, lists, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	rows := strings.Split(strings.TrimSpace(table.String()), "\n")[1:]
+	for i, w := range want {
+		if i >= len(rows) || !strings.HasSuffix(strings.TrimSpace(rows[i]), w) {
+			t.Errorf("table row %d = %q, want name %q", i, rows, w)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := NewJSONRendererWithClient(client).Render(context.Background(), &out, lists, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var got []struct {
+		Items []struct {
+			Name   string         `json:"name"`
+			Fields map[string]any `json:"fields"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil || len(got) != 1 || len(got[0].Items) != 3 {
+		t.Fatalf("json: %v\n%s", err, out.String())
+	}
+	for i, w := range want {
+		if it := got[0].Items[i]; it.Fields["name"] != w {
+			t.Errorf("json item %d: name %q, fields[name] %v; want %q", i, it.Name, it.Fields["name"], w)
+		}
+	}
+}
+
+func TestRowNumbersFollowSortAndResetPerList(t *testing.T) {
+	quotes := map[string]map[string]any{}
+	for sym, price := range map[string]float64{"A": 10, "B": 30, "C": 20, "D": 5, "E": 50} {
+		quotes[sym] = map[string]any{"price": map[string]any{"regularMarketPrice": num(price, "%.2f")}}
+	}
+	cols := []string{"#", "sym", "price"}
+	lists := []types.Watchlist{
+		{Name: "one", Columns: cols, Items: []types.Item{{Sym: "A"}, {Sym: "B"}, {Sym: "C"}}},
+		{Name: "two", Columns: cols, Items: []types.Item{{Sym: "D"}, {Sym: "E"}}},
+	}
+	var buf bytes.Buffer
+	if err := NewTableRendererWithClient(stubClient(t, quotes)).Render(context.Background(), &buf, lists, RenderOptions{SortBy: "price", SortDesc: true}); err != nil {
+		t.Fatal(err)
+	}
+	want := "ONE\n" +
+		" #  SYM  PRICE \n" +
+		" 1  B    30.00 \n" +
+		" 2  C    20.00 \n" +
+		" 3  A    10.00 \n" +
+		"\n" +
+		"TWO\n" +
+		" #  SYM  PRICE \n" +
+		" 1  E    50.00 \n" +
+		" 2  D     5.00 \n"
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if mods := columns.RequiredModules([]string{"#"}); len(mods) != 0 {
+		t.Errorf("# fetches %v, want nothing", mods)
+	}
+}
+
+func TestTransposeLimitCheckedBeforeFetch(t *testing.T) {
+	store := &recordingStore{stubStore: stubStore{}}
+	client := yfgo.NewClient(
+		yfgo.WithHTTPClient(&http.Client{Transport: offlineTransport{}}),
+		yfgo.WithCacheStore(store),
+	)
+	list := types.Watchlist{Name: "big", Columns: []string{"sym", "price"}}
+	for i := 0; i <= TransposeMaxSymbols; i++ {
+		list.Items = append(list.Items, types.Item{Sym: fmt.Sprintf("S%d", i)})
+	}
+	r := NewTableRendererWithClient(client)
+	err := r.Render(context.Background(), io.Discard, []types.Watchlist{list}, RenderOptions{Transpose: true})
+	var le *LimitError
+	if !errors.As(err, &le) {
+		t.Fatalf("got %v, want a *LimitError", err)
+	}
+	if len(store.keys) != 0 {
+		t.Errorf("fetched %d quotes before failing", len(store.keys))
+	}
+	// --limit brings the list within bounds
+	if err := r.Render(context.Background(), io.Discard, []types.Watchlist{list}, RenderOptions{Transpose: true, Limit: TransposeMaxSymbols}); err != nil {
+		t.Errorf("with --limit: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package render
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// DefaultTheme is the table theme used when RenderOptions.Theme is empty.
+const DefaultTheme = "dark"
+
+// themes maps theme names to the go-pretty styles behind table and summary
+// output. Only their colors show: borders and separators are always off.
+var themes = map[string]table.Style{
+	"dark":    table.StyleColoredDark,
+	"bright":  table.StyleColoredBright,
+	"blue":    table.StyleColoredBlackOnBlueWhite,
+	"cyan":    table.StyleColoredBlackOnCyanWhite,
+	"green":   table.StyleColoredBlackOnGreenWhite,
+	"magenta": table.StyleColoredBlackOnMagentaWhite,
+	"red":     table.StyleColoredBlackOnRedWhite,
+	"yellow":  table.StyleColoredBlackOnYellowWhite,
+	"plain":   table.StyleDefault,
+}
+
+// ThemeNames returns the known theme names, sorted.
+func ThemeNames() []string {
+	names := make([]string, 0, len(themes))
+	for k := range themes {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsTheme reports whether name is a known theme (case-insensitive).
+func IsTheme(name string) bool {
+	_, ok := themes[strings.ToLower(strings.TrimSpace(name))]
+	return ok
+}
+
+// themeStyle returns the style for name, falling back to DefaultTheme.
+func themeStyle(name string) table.Style {
+	if st, ok := themes[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return st
+	}
+	return themes[DefaultTheme]
+}
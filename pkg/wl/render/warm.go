@@ -0,0 +1,99 @@
+package render
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	yfgo "github.com/komsit37/yf-go"
+
+	"github.com/komsit37/wl/pkg/wl/columns"
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// DefaultConcurrency is how many symbols are fetched at once by default.
+const DefaultConcurrency = 8
+
+// warmJob is one unique symbol with everything any list needs from it.
+type warmJob struct {
+	sym   string
+	mods  map[yfgo.QuoteSummaryModule]struct{}
+	chart bool
+}
+
+// WarmCache fetches every unique symbol's quoteSummary modules (and chart
+// history when a spark column needs it) with up to opts.Concurrency fetches
+// in flight, so rendering then hits the client cache even for symbols shared
+// across lists. The modules cover each list's columns plus opts' sort and
+// group columns. A failed symbol doesn't stop the others; failures are
+// returned in list order. Once ctx is done the remaining fetches fail fast.
+func WarmCache(ctx context.Context, client *yfgo.Client, lists []types.Watchlist, opts RenderOptions) []FetchError {
+	var jobs []*warmJob
+	bySym := map[string]*warmJob{}
+	for _, l := range lists {
+		cols := append([]string(nil), l.Columns...)
+		if !IsFileOrder(opts.SortBy) {
+			for _, k := range ParseSortKeys(opts.SortBy, opts.SortDesc) {
+				cols = append(cols, k.Col)
+			}
+		}
+		if g := strings.TrimSpace(opts.GroupBy); g != "" {
+			cols = append(cols, g)
+		}
+		mods := columns.RequiredModules(cols)
+		chart := columns.NeedsModule(cols, columns.ModuleChart)
+		if len(mods) == 0 && !chart {
+			continue
+		}
+		for _, it := range l.Items {
+			sym := strings.TrimSpace(it.Sym)
+			if sym == "" {
+				continue
+			}
+			j, ok := bySym[strings.ToUpper(sym)]
+			if !ok {
+				j = &warmJob{sym: sym, mods: map[yfgo.QuoteSummaryModule]struct{}{}}
+				bySym[strings.ToUpper(sym)] = j
+				jobs = append(jobs, j)
+			}
+			for _, m := range mods {
+				j.mods[m] = struct{}{}
+			}
+			j.chart = j.chart || chart
+		}
+	}
+	errs := make([]error, len(jobs))
+	var mu sync.Mutex
+	done := 0
+	runPool(len(jobs), opts.Concurrency, func(i int) {
+		if opts.Progress != nil {
+			defer func() {
+				mu.Lock()
+				done++
+				opts.Progress(done, len(jobs))
+				mu.Unlock()
+			}()
+		}
+		j := jobs[i]
+		if len(j.mods) > 0 {
+			mods := make([]yfgo.QuoteSummaryModule, 0, len(j.mods))
+			for m := range j.mods {
+				mods = append(mods, m)
+			}
+			if _, err := quoteSummary(ctx, client, j.sym, mods, opts.FetchRetries); err != nil {
+				errs[i] = err
+			}
+		}
+		if j.chart {
+			fetchCloses(ctx, client, j.sym, opts.SparkDays)
+		}
+	})
+
+	var out []FetchError
+	for i, err := range errs {
+		if err != nil {
+			out = append(out, FetchError{Sym: jobs[i].sym, Err: err})
+		}
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package render
+
+import (
+	"os"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/mattn/go-runewidth"
+)
+
+// widthCond measures display width for the padding done outside go-pretty
+// (line and compact output, side-by-side tables).
+var widthCond = runewidth.NewCondition()
+
+func init() {
+	SetEastAsianWidth(os.Getenv("RUNEWIDTH_EASTASIAN") == "1")
+}
+
+// SetEastAsianWidth chooses how ambiguous-width characters (▲, ±, sparkline
+// blocks) are measured: two cells when wide, else one. Full-width CJK text is
+// always two cells. It applies to go-pretty tables and wl's own padding
+// alike. The default is narrow unless RUNEWIDTH_EASTASIAN=1, rather than
+// following a CJK locale, since most terminals draw these characters narrow.
+func SetEastAsianWidth(wide bool) {
+	widthCond.EastAsianWidth = wide
+	text.OverrideRuneWidthEastAsianWidth(wide)
+}
@@ -0,0 +1,50 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+func TestFullWidthNamesAlign(t *testing.T) {
+	client := stubClient(t, map[string]map[string]any{
+		"7203.T": {"price": map[string]any{"regularMarketPrice": num(2850, "%.2f")}},
+		"AAPL":   {"price": map[string]any{"regularMarketPrice": num(180, "%.2f")}},
+		"6758.T": {"price": map[string]any{"regularMarketPrice": num(3100.5, "%.2f")}},
+	})
+	list := types.Watchlist{Name: "mixed", Columns: []string{"sym", "name", "price"}, Items: []types.Item{
+		{Sym: "7203.T", Name: "トヨタ自動車"},
+		{Sym: "AAPL", Name: "Apple"},
+		{Sym: "6758.T", Name: "ソニーG ±"}, // ± is ambiguous width: narrow by default
+	}}
+	defer SetEastAsianWidth(false)
+	for _, wide := range []bool{false, true} {
+		SetEastAsianWidth(wide)
+		cond := &runewidth.Condition{EastAsianWidth: wide}
+		for name, r := range map[string]Renderer{
+			"table": NewTableRendererWithClient(client),
+			"line":  NewLineRendererWithClient(client),
+		} {
+			var buf bytes.Buffer
+			if err := r.Render(context.Background(), &buf, []types.Watchlist{list}, RenderOptions{}); err != nil {
+				t.Fatal(err)
+			}
+			// price is the last, right-aligned column: every row ends at the same cell
+			lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+			want := cond.StringWidth(strings.TrimRight(lines[len(lines)-1], " "))
+			for _, l := range lines {
+				if !strings.Contains(l, ".") {
+					continue // header
+				}
+				if got := cond.StringWidth(strings.TrimRight(l, " ")); got != want {
+					t.Errorf("%s (wide %v): %q is %d cells wide, want %d:\n%s", name, wide, l, got, want, buf.String())
+				}
+			}
+		}
+	}
+}
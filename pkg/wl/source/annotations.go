@@ -0,0 +1,81 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// Annotations are per-symbol fields from a sidecar file, keyed by upper-case
+// symbol.
+type Annotations map[string]map[string]any
+
+// LoadAnnotations reads a sidecar YAML mapping symbols to fields, e.g.
+//
+//	AAPL: {note: long term, cost: 150, shares: 10}
+//
+// Failures are LoadErrors.
+func LoadAnnotations(path string) (Annotations, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &LoadError{Err: fmt.Errorf("annotations: %w", err)}
+	}
+	root, err := decodeYAML(data)
+	if err != nil {
+		return nil, &LoadError{Err: fmt.Errorf("annotations %s: %w", path, err)}
+	}
+	if root == nil {
+		return Annotations{}, nil
+	}
+	m, ok := root.(map[string]any)
+	if !ok {
+		return nil, &LoadError{Err: fmt.Errorf("annotations %s: expected a map of symbol to fields", path)}
+	}
+	out := make(Annotations, len(m))
+	for sym, v := range m {
+		fields, ok := v.(map[string]any)
+		if !ok {
+			if v == nil {
+				continue
+			}
+			return nil, &LoadError{Err: fmt.Errorf("annotations %s: %s: expected a map of fields", path, sym)}
+		}
+		out[strings.ToUpper(strings.TrimSpace(sym))] = fields
+	}
+	return out, nil
+}
+
+// Apply merges each item's annotation (matched case-insensitively by
+// symbol) into its Fields. Fields the item already has win unless override
+// is set; a "name" annotation also fills Item.Name under the same rule.
+func (a Annotations) Apply(lists []types.Watchlist, override bool) {
+	if len(a) == 0 {
+		return
+	}
+	for li := range lists {
+		for ii := range lists[li].Items {
+			it := &lists[li].Items[ii]
+			fields, ok := a[strings.ToUpper(strings.TrimSpace(it.Sym))]
+			if !ok {
+				continue
+			}
+			if it.Fields == nil {
+				it.Fields = map[string]any{}
+			}
+			for k, v := range fields {
+				if k == "sym" {
+					continue
+				}
+				if _, exists := it.Fields[k]; exists && !override {
+					continue
+				}
+				it.Fields[k] = v
+				if k == "name" && v != nil {
+					it.Name = fmt.Sprint(v)
+				}
+			}
+		}
+	}
+}
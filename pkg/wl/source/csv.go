@@ -0,0 +1,101 @@
+package source
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// CSVSource loads a single watchlist from a CSV file with a header row.
+// The symbol column may be named sym, symbol or ticker; an optional name
+// column fills Item.Name and every other non-empty cell becomes a string field.
+type CSVSource struct{}
+
+// Load expects spec to be a string filepath.
+func (CSVSource) Load(_ context.Context, spec any) ([]types.Watchlist, error) {
+	path, ok := spec.(string)
+	if !ok {
+		return nil, fmt.Errorf("csv source expects filepath string spec")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	items, err := parseCSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return []types.Watchlist{{Name: name, Items: items}}, nil
+}
+
+// parseCSV reads items from CSV data; see CSVSource for the column rules.
+func parseCSV(r io.Reader) ([]types.Item, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("empty csv: missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	symIdx, nameIdx := -1, -1
+	for i, h := range header {
+		h = strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")) // tolerate a UTF-8 BOM
+		header[i] = h
+		switch strings.ToLower(h) {
+		case "sym", "symbol", "ticker":
+			if symIdx < 0 {
+				symIdx = i
+			}
+		case "name":
+			if nameIdx < 0 {
+				nameIdx = i
+			}
+		}
+	}
+	if symIdx < 0 {
+		return nil, fmt.Errorf("no symbol column found (expected sym, symbol or ticker) in header: %s", strings.Join(header, ","))
+	}
+
+	var items []types.Item
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if symIdx >= len(rec) || strings.TrimSpace(rec[symIdx]) == "" {
+			continue
+		}
+		it := types.Item{Sym: strings.TrimSpace(rec[symIdx]), Fields: map[string]any{}}
+		it.Fields["sym"] = it.Sym
+		for i, v := range rec {
+			v = strings.TrimSpace(v)
+			if i == symIdx || i >= len(header) || v == "" || header[i] == "" {
+				continue
+			}
+			if i == nameIdx {
+				it.Name = v
+				it.Fields["name"] = v
+				continue
+			}
+			it.Fields[header[i]] = v
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
@@ -2,18 +2,109 @@ package source
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strings"
+
+	_ "modernc.org/sqlite" // registers the pure-Go "sqlite" driver
 
 	"github.com/komsit37/wl/pkg/wl/types"
 )
 
-// DBSource is a placeholder for a future database-backed source.
-// It currently returns a not implemented error.
+// DBSource loads watchlists from a SQLite database with the schema:
+//
+//	watchlists(name TEXT)
+//	items(watchlist_name TEXT, sym TEXT, name TEXT, extra_json TEXT)
+//
+// Lists keep the order of the watchlists table; items keep insertion order.
+// extra_json, when present, is a JSON object merged into Item.Fields.
 type DBSource struct {
 	DSN    string
 	Driver string
 }
 
-func (DBSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error) { //nolint:revive
-	return nil, fmt.Errorf("db source not implemented")
+// Load opens the DSN (spec, if a non-empty string, overrides DSN).
+func (d DBSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error) {
+	dsn := d.DSN
+	if s, ok := spec.(string); ok && strings.TrimSpace(s) != "" {
+		dsn = s
+	}
+	if strings.TrimSpace(dsn) == "" {
+		return nil, fmt.Errorf("db source requires a DSN")
+	}
+	driver := d.Driver
+	if driver == "" {
+		driver = "sqlite"
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+	defer db.Close()
+
+	var lists []types.Watchlist
+	index := map[string]int{}
+	rows, err := db.QueryContext(ctx, `SELECT name FROM watchlists ORDER BY rowid`)
+	if err != nil {
+		return nil, fmt.Errorf("query watchlists: %w", err)
+	}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan watchlist: %w", err)
+		}
+		if _, dup := index[name]; dup {
+			continue
+		}
+		index[name] = len(lists)
+		lists = append(lists, types.Watchlist{Name: name})
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("query watchlists: %w", err)
+	}
+	rows.Close()
+
+	rows, err = db.QueryContext(ctx, `SELECT watchlist_name, sym, name, extra_json FROM items ORDER BY rowid`)
+	if err != nil {
+		return nil, fmt.Errorf("query items: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			listName         string
+			sym, name, extra sql.NullString
+		)
+		if err := rows.Scan(&listName, &sym, &name, &extra); err != nil {
+			return nil, fmt.Errorf("scan item: %w", err)
+		}
+		it := types.Item{Fields: map[string]any{}}
+		if extra.Valid && strings.TrimSpace(extra.String) != "" {
+			if err := json.Unmarshal([]byte(extra.String), &it.Fields); err != nil {
+				return nil, fmt.Errorf("item %s in %s: invalid extra_json: %w", sym.String, listName, err)
+			}
+		}
+		if sym.Valid && sym.String != "" {
+			it.Sym = sym.String
+			it.Fields["sym"] = it.Sym
+		}
+		if name.Valid && name.String != "" {
+			it.Name = name.String
+			it.Fields["name"] = it.Name
+		}
+		// Items referencing an undeclared watchlist still get a list.
+		i, ok := index[listName]
+		if !ok {
+			i = len(lists)
+			index[listName] = i
+			lists = append(lists, types.Watchlist{Name: listName})
+		}
+		lists[i].Items = append(lists[i].Items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query items: %w", err)
+	}
+	return lists, nil
 }
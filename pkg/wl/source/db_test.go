@@ -0,0 +1,78 @@
+package source
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// initDB creates a SQLite file with the DBSource schema and runs stmts.
+func initDB(t *testing.T, stmts ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wl.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	stmts = append([]string{
+		`CREATE TABLE watchlists(name TEXT)`,
+		`CREATE TABLE items(watchlist_name TEXT, sym TEXT, name TEXT, extra_json TEXT)`,
+	}, stmts...)
+	for _, s := range stmts {
+		if _, err := db.Exec(s); err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+	}
+	return path
+}
+
+func TestDBSourceLoad(t *testing.T) {
+	path := initDB(t,
+		`INSERT INTO watchlists(name) VALUES ('us'), ('jp'), ('empty')`,
+		`INSERT INTO items VALUES
+			('jp', '7203.T', 'Toyota', NULL),
+			('us', 'AAPL', 'Apple', '{"cost": 150, "tags": ["growth"]}'),
+			('us', 'MSFT', NULL, ''),
+			('etf', 'SPY', NULL, NULL)`,
+	)
+	lists, err := DBSource{DSN: path}.Load(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, l := range lists {
+		names = append(names, l.Name)
+	}
+	// declared lists first in table order, then lists only items mention
+	if want := []string{"us", "jp", "empty", "etf"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("lists = %v, want %v", names, want)
+	}
+	us := lists[0].Items
+	if len(us) != 2 || us[0].Sym != "AAPL" || us[0].Name != "Apple" || us[1].Sym != "MSFT" || us[1].Name != "" {
+		t.Fatalf("us items = %+v", us)
+	}
+	if us[0].Fields["cost"] != 150.0 || !reflect.DeepEqual(us[0].Fields["tags"], []any{"growth"}) || us[0].Fields["sym"] != "AAPL" {
+		t.Errorf("AAPL fields = %v", us[0].Fields)
+	}
+	if len(lists[1].Items) != 1 || lists[1].Items[0].Name != "Toyota" || len(lists[2].Items) != 0 {
+		t.Errorf("jp/empty = %+v / %+v", lists[1].Items, lists[2].Items)
+	}
+}
+
+func TestDBSourceErrors(t *testing.T) {
+	if _, err := (DBSource{}).Load(context.Background(), nil); err == nil {
+		t.Error("want an error without a DSN")
+	}
+	bad := initDB(t, `INSERT INTO items VALUES ('us', 'AAPL', NULL, '{oops')`)
+	if _, err := (DBSource{}).Load(context.Background(), bad); err == nil || !strings.Contains(err.Error(), "invalid extra_json") {
+		t.Errorf("bad extra_json: %v", err)
+	}
+	noSchema := filepath.Join(t.TempDir(), "none.db")
+	if _, err := (DBSource{DSN: noSchema}).Load(context.Background(), nil); err == nil || !strings.Contains(err.Error(), "query watchlists") {
+		t.Errorf("missing tables: %v", err)
+	}
+}
@@ -0,0 +1,147 @@
+package source
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// exportGroup is a node of the group tree EncodeYAML rebuilds from list
+// names; list is nil for groups that only hold other groups.
+type exportGroup struct {
+	name     string
+	list     *types.Watchlist
+	children []*exportGroup
+}
+
+// EncodeYAML writes lists as one YAML document in the loader's format,
+// nesting groups by the "/"-separated segments of each list name, so
+// loading the result yields the same lists. Each group carries its list's
+// effective columns and col_set. Items start with sym and name; other fields
+// follow in key order.
+func EncodeYAML(w io.Writer, lists []types.Watchlist) error {
+	root := &exportGroup{}
+	for i := range lists {
+		node := root
+		if name := strings.TrimSpace(lists[i].Name); name != "" {
+			segs := strings.Split(name, "/")
+			for j, seg := range segs {
+				node = node.child(seg, j == len(segs)-1)
+			}
+		}
+		if node.list != nil {
+			// A second unnamed list goes in an unnamed group
+			node = root.child("", true)
+		}
+		node.list = &lists[i]
+	}
+
+	doc := mappingNode()
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	if err := root.appendContent(seq); err != nil {
+		return err
+	}
+	addPair(doc, "watchlist", seq)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// child returns the subgroup called name, adding it when missing. For the
+// last segment of a list name a subgroup that already holds a list is
+// skipped, so duplicate names stay separate groups.
+func (g *exportGroup) child(name string, last bool) *exportGroup {
+	for _, c := range g.children {
+		if c.name == name && (!last || c.list == nil) {
+			return c
+		}
+	}
+	c := &exportGroup{name: name}
+	g.children = append(g.children, c)
+	return c
+}
+
+// appendContent appends g's items, then its subgroups, to seq.
+func (g *exportGroup) appendContent(seq *yaml.Node) error {
+	if g.list != nil {
+		for _, it := range g.list.Items {
+			n, err := itemNode(it)
+			if err != nil {
+				return err
+			}
+			seq.Content = append(seq.Content, n)
+		}
+	}
+	for _, c := range g.children {
+		n := mappingNode()
+		addPair(n, "name", scalarNode(c.name))
+		if c.list != nil {
+			if len(c.list.Columns) > 0 {
+				addPair(n, "columns", flowSeqNode(c.list.Columns))
+			}
+			if len(c.list.ColSet) > 0 {
+				addPair(n, "col_set", flowSeqNode(c.list.ColSet))
+			}
+		}
+		sub := &yaml.Node{Kind: yaml.SequenceNode}
+		if err := c.appendContent(sub); err != nil {
+			return err
+		}
+		addPair(n, "watchlist", sub)
+		seq.Content = append(seq.Content, n)
+	}
+	return nil
+}
+
+// itemNode encodes an item with sym and name first.
+func itemNode(it types.Item) (*yaml.Node, error) {
+	n := mappingNode()
+	if it.Sym != "" {
+		addPair(n, "sym", scalarNode(it.Sym))
+	}
+	if it.Name != "" {
+		addPair(n, "name", scalarNode(it.Name))
+	}
+	keys := make([]string, 0, len(it.Fields))
+	for k := range it.Fields {
+		if k != "sym" && k != "name" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := &yaml.Node{}
+		if err := v.Encode(it.Fields[k]); err != nil {
+			return nil, err
+		}
+		addPair(n, k, v)
+	}
+	return n, nil
+}
+
+func mappingNode() *yaml.Node { return &yaml.Node{Kind: yaml.MappingNode} }
+
+func scalarNode(s string) *yaml.Node {
+	n := &yaml.Node{}
+	_ = n.Encode(s) // quotes values such as "7203" or "yes" that would not read back as strings
+	return n
+}
+
+func flowSeqNode(vals []string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, v := range vals {
+		n.Content = append(n.Content, scalarNode(v))
+	}
+	return n
+}
+
+func addPair(m *yaml.Node, key string, val *yaml.Node) {
+	m.Content = append(m.Content, scalarNode(key), val)
+}
@@ -0,0 +1,109 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// DefaultGitTTL is how long a cached checkout is reused before fetching again.
+const DefaultGitTTL = 15 * time.Minute
+
+// gitStampFile marks the last successful sync inside a cached checkout.
+const gitStampFile = ".wl-synced"
+
+// GitSource loads watchlists from a git repository. The repository is
+// shallow-cloned into CacheDir and refreshed when older than TTL; the
+// spec is the file or directory path inside the repository.
+type GitSource struct {
+	Repo     string
+	CacheDir string
+	TTL      time.Duration
+}
+
+// Load expects spec to be a string path relative to the repository root.
+// An empty path loads every YAML file in the repository.
+func (g GitSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error) {
+	rel, ok := spec.(string)
+	if !ok && spec != nil {
+		return nil, fmt.Errorf("git source expects path string spec")
+	}
+	if strings.TrimSpace(g.Repo) == "" {
+		return nil, fmt.Errorf("git source requires a repository URL")
+	}
+	rel = strings.TrimSpace(rel)
+	if rel != "" && !filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("git source path must be inside the repository: %s", rel)
+	}
+	dir, err := g.sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return YAMLSource{}.Load(ctx, filepath.Join(dir, rel))
+}
+
+// CheckoutDir returns the cache directory used for the repository.
+func (g GitSource) CheckoutDir() string {
+	sum := sha1.Sum([]byte(g.Repo)) // #nosec G401 -- used for a stable directory name only
+	return filepath.Join(g.CacheDir, hex.EncodeToString(sum[:8]))
+}
+
+// sync clones the repository on first use, or fetches and resets it once
+// the previous sync is older than the TTL.
+func (g GitSource) sync(ctx context.Context) (string, error) {
+	dir := g.CheckoutDir()
+	ttl := g.TTL
+	if ttl <= 0 {
+		ttl = DefaultGitTTL
+	}
+	stamp := filepath.Join(dir, ".git", gitStampFile)
+	if st, err := os.Stat(stamp); err == nil {
+		if time.Since(st.ModTime()) < ttl {
+			return dir, nil
+		}
+		if err := runGit(ctx, dir, "fetch", "--depth", "1", "origin"); err != nil {
+			return "", err
+		}
+		if err := runGit(ctx, dir, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return "", err
+		}
+	} else {
+		if err := os.MkdirAll(g.CacheDir, 0o755); err != nil {
+			return "", err
+		}
+		// Remove any partial checkout left behind by an interrupted clone.
+		_ = os.RemoveAll(dir)
+		// "--" keeps a repo value such as --upload-pack=... from being read as an option
+		if err := runGit(ctx, "", "clone", "--depth", "1", "--", g.Repo, dir); err != nil {
+			return "", err
+		}
+	}
+	if err := os.WriteFile(stamp, []byte(time.Now().UTC().Format(time.RFC3339)+"\n"), 0o644); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return fmt.Errorf("git %s: %w: %s", args[0], err, msg)
+	}
+	return nil
+}
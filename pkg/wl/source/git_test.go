@@ -0,0 +1,86 @@
+package source
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initRepo creates a git repository holding the given files, committed.
+func initRepo(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=wl", "-c", "user.email=wl@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	return dir
+}
+
+func TestGitSourceLoadsPathInsideRepo(t *testing.T) {
+	repo := initRepo(t, map[string]string{
+		"lists/us.yaml": "watchlist:\n  - sym: AAPL\n  - sym: MSFT\n",
+		"README.md":     "not a watchlist\n",
+	})
+	g := GitSource{Repo: repo, CacheDir: t.TempDir()}
+	lists, err := g.Load(context.Background(), "lists/us.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 1 || len(lists[0].Items) != 2 || lists[0].Items[0].Sym != "AAPL" {
+		t.Fatalf("unexpected lists: %+v", lists)
+	}
+	if _, err := os.Stat(filepath.Join(g.CheckoutDir(), ".git", gitStampFile)); err != nil {
+		t.Fatalf("sync stamp missing: %v", err)
+	}
+}
+
+func TestGitSourceRejectsPathsOutsideRepo(t *testing.T) {
+	g := GitSource{Repo: "unused", CacheDir: t.TempDir()}
+	for _, rel := range []string{"../other.yaml", "lists/../../x.yaml", "/etc/passwd"} {
+		if _, err := g.Load(context.Background(), rel); err == nil {
+			t.Errorf("Load(%q): expected error", rel)
+		}
+	}
+}
+
+func TestGitSourceRepoIsNotAnOption(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	marker := filepath.Join(t.TempDir(), "pwned")
+	g := GitSource{Repo: "--upload-pack=touch " + marker, CacheDir: t.TempDir()}
+	_, err := g.Load(context.Background(), "")
+	if err == nil {
+		t.Fatal("expected clone of an option-like repo to fail")
+	}
+	// git must have taken the value as the repository, not as --upload-pack
+	if !strings.Contains(err.Error(), "'"+g.Repo+"'") {
+		t.Fatalf("repo value was not passed as a repository: %v", err)
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Fatal("repo value was run as a git option")
+	}
+}
@@ -0,0 +1,94 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// hasGlobMeta reports whether spec contains glob metacharacters.
+func hasGlobMeta(spec string) bool {
+	return strings.ContainsAny(spec, "*?[")
+}
+
+// globFiles expands a glob pattern where "**" matches any number of
+// directories (e.g. "us/**/*.yaml"). Like directory loading it keeps only
+// .yaml/.yml files, so "us/**" skips READMEs and backups. It returns the
+// literal directory the pattern starts from, used as the naming root, and
+// the sorted matches.
+func globFiles(pattern string) (string, []string, error) {
+	segs := strings.Split(filepath.ToSlash(pattern), "/")
+	n := 0
+	for n < len(segs) && !hasGlobMeta(segs[n]) {
+		n++
+	}
+	root := strings.Join(segs[:n], "/")
+	if root == "" {
+		root = "."
+		if strings.HasPrefix(pattern, "/") {
+			root = "/"
+		}
+	}
+	root = filepath.FromSlash(root)
+	rest := segs[n:]
+	for _, s := range rest {
+		if _, err := path.Match(s, ""); err != nil {
+			return "", nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+	}
+
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := strings.ToLower(filepath.Ext(d.Name())); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		if matchSegments(rest, strings.Split(filepath.ToSlash(rel), "/")) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return "", nil, err
+	}
+	if len(files) == 0 {
+		return "", nil, fmt.Errorf("no files matched %q", pattern)
+	}
+	sort.Strings(files)
+	return root, files, nil
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" segment matches zero or more path segments.
+func matchSegments(pat, name []string) bool {
+	if len(pat) == 0 {
+		return len(name) == 0
+	}
+	if pat[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pat[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pat[0], name[0]); !ok {
+		return false
+	}
+	return matchSegments(pat[1:], name[1:])
+}
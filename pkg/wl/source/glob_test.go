@@ -0,0 +1,60 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGlobFilesKeepsOnlyYAML(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"us/tech.yaml":     "- sym: AAPL\n",
+		"us/sub/banks.yml": "- sym: JPM\n",
+		"us/README.md":     "# notes\n",
+		"us/tech.yaml.bak": "- sym: OLD\n",
+		"jp/autos.yaml":    "- sym: 7203.T\n",
+	})
+	root, files, err := globFiles(filepath.ToSlash(dir) + "/us/**")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "us"); root != want {
+		t.Errorf("root = %q, want %q", root, want)
+	}
+	want := []string{filepath.Join(dir, "us", "sub", "banks.yml"), filepath.Join(dir, "us", "tech.yaml")}
+	if !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	cases := []struct {
+		pat, name []string
+		want      bool
+	}{
+		{[]string{"**", "*.yaml"}, []string{"a.yaml"}, true},
+		{[]string{"**", "*.yaml"}, []string{"x", "y", "a.yaml"}, true},
+		{[]string{"*", "*.yaml"}, []string{"a.yaml"}, false},
+		{[]string{"us", "*.yaml"}, []string{"jp", "a.yaml"}, false},
+	}
+	for _, c := range cases {
+		if got := matchSegments(c.pat, c.name); got != c.want {
+			t.Errorf("matchSegments(%v, %v) = %v, want %v", c.pat, c.name, got, c.want)
+		}
+	}
+}
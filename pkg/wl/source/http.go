@@ -0,0 +1,65 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// DefaultHTTPTimeout bounds a remote watchlist fetch.
+const DefaultHTTPTimeout = 15 * time.Second
+
+var httpClient = &http.Client{Timeout: DefaultHTTPTimeout}
+
+// isURL reports whether spec names a remote http(s) watchlist.
+func isURL(spec string) bool {
+	lower := strings.ToLower(spec)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// loadYAMLURL fetches a YAML watchlist over HTTP(S). Unnamed lists are named
+// after the last path segment of the URL without its extension.
+func loadYAMLURL(ctx context.Context, rawURL string) ([]types.Watchlist, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", rawURL, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: unexpected status %s", rawURL, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", rawURL, err)
+	}
+	lists, err := parseYAML(data, rawURL, false)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rawURL, err)
+	}
+	base := path.Base(u.Path)
+	base = strings.TrimSuffix(base, path.Ext(base))
+	if base == "." || base == "/" || base == "" {
+		base = u.Host
+	}
+	for i := range lists {
+		if strings.TrimSpace(lists[i].Name) == "" {
+			lists[i].Name = base
+		}
+	}
+	return lists, nil
+}
@@ -0,0 +1,158 @@
+package source
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxIncludeDepth bounds nested includes.
+const maxIncludeDepth = 10
+
+// decodeYAML unmarshals data into generic values with string map keys.
+func decodeYAML(data []byte) (any, error) {
+	var root any
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	return normalizeYAML(root), nil
+}
+
+// normalizeYAML converts maps with non-string keys to map[string]any.
+func normalizeYAML(v any) any {
+	switch m := v.(type) {
+	case map[any]any:
+		mm := make(map[string]any, len(m))
+		for k, val := range m {
+			mm[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return mm
+	case map[string]any:
+		for k, val := range m {
+			m[k] = normalizeYAML(val)
+		}
+		return m
+	case []any:
+		out := make([]any, 0, len(m))
+		for _, e := range m {
+			out = append(out, normalizeYAML(e))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// expandIncludes replaces {include: path} entries of watchlist sequences with
+// the referenced file's items. file is the document being expanded ("" or
+// "-" for stdin, resolved against the working directory) and chain holds the
+// absolute paths of the files including it, for cycle detection.
+func expandIncludes(node any, file string, chain []string) (any, error) {
+	switch n := node.(type) {
+	case []any:
+		out := make([]any, 0, len(n))
+		for _, e := range n {
+			if p, ok := includePath(e); ok {
+				items, err := loadInclude(p, file, chain)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, items...)
+				continue
+			}
+			ex, err := expandIncludes(e, file, chain)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ex)
+		}
+		return out, nil
+	case map[string]any:
+		if child, ok := n["watchlist"]; ok {
+			ex, err := expandIncludes(child, file, chain)
+			if err != nil {
+				return nil, err
+			}
+			n["watchlist"] = ex
+		}
+		return n, nil
+	default:
+		return node, nil
+	}
+}
+
+// includePath reports whether e is an include entry and returns its path.
+func includePath(e any) (string, bool) {
+	m, ok := e.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	p, ok := m["include"].(string)
+	if !ok {
+		return "", false
+	}
+	if _, ok := m["sym"]; ok {
+		return "", false
+	}
+	if _, ok := m["watchlist"]; ok {
+		return "", false
+	}
+	return strings.TrimSpace(p), true
+}
+
+// loadInclude reads an included file relative to the including file and
+// returns its watchlist entries, expanded in turn.
+func loadInclude(p, file string, chain []string) ([]any, error) {
+	if isURL(file) {
+		return nil, fmt.Errorf("include %q: includes are not supported in remote watchlists", p)
+	}
+	if p == "" {
+		return nil, fmt.Errorf("include: empty path")
+	}
+	if len(chain) >= maxIncludeDepth {
+		return nil, fmt.Errorf("include %q: nested deeper than %d files", p, maxIncludeDepth)
+	}
+	target := p
+	if !filepath.IsAbs(target) {
+		dir := "."
+		if file != "" && file != "-" {
+			dir = filepath.Dir(file)
+		}
+		target = filepath.Join(dir, target)
+	}
+	abs, err := filepath.Abs(target)
+	if err != nil {
+		return nil, fmt.Errorf("include %q: %w", p, err)
+	}
+	for _, c := range chain {
+		if c == abs {
+			return nil, fmt.Errorf("include cycle: %s -> %s", strings.Join(chain, " -> "), abs)
+		}
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		return nil, fmt.Errorf("include %q: %w", p, err)
+	}
+	root, err := decodeYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("include %s: %w", target, err)
+	}
+	// An included file may be a full watchlist file or a bare list of items.
+	node := root
+	if m, ok := root.(map[string]any); ok {
+		if child, ok := m["watchlist"]; ok {
+			node = child
+		}
+	}
+	ex, err := expandIncludes(node, target, append(append([]string(nil), chain...), abs))
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := ex.([]any); ok {
+		return list, nil
+	}
+	return []any{ex}, nil
+}
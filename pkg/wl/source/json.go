@@ -0,0 +1,99 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// jsonList mirrors the shape written by render.JSONRenderer so its output
+// can be loaded back.
+type jsonList struct {
+	Name    string     `json:"name"`
+	Columns []string   `json:"columns"`
+	Items   []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	Sym    string         `json:"sym"`
+	Name   string         `json:"name"`
+	Fields map[string]any `json:"fields"`
+}
+
+// JSONSource loads watchlists from a file produced by `wl -o json`.
+type JSONSource struct{}
+
+// Load expects spec to be a string filepath.
+func (JSONSource) Load(_ context.Context, spec any) ([]types.Watchlist, error) {
+	path, ok := spec.(string)
+	if !ok {
+		return nil, fmt.Errorf("json source expects filepath string spec")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := parseJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return lists, nil
+}
+
+// jsonEnvelope is the --json-meta shape: the lists plus snapshot metadata,
+// which is ignored on load.
+type jsonEnvelope struct {
+	Lists []jsonList `json:"lists"`
+}
+
+// parseJSON decodes the JSON renderer's top-level array of lists, or the
+// object written with --json-meta.
+func parseJSON(data []byte) ([]types.Watchlist, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '[' && trimmed[0] != '{') {
+		return nil, fmt.Errorf("invalid json: expected top-level array of watchlists")
+	}
+	var in []jsonList
+	var err error
+	if trimmed[0] == '{' {
+		var env jsonEnvelope
+		err = json.Unmarshal(data, &env)
+		in = env.Lists
+	} else {
+		err = json.Unmarshal(data, &in)
+	}
+	if err != nil {
+		var syn *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syn):
+			return nil, fmt.Errorf("invalid json at byte %d: %w", syn.Offset, err)
+		case errors.As(err, &typ):
+			return nil, fmt.Errorf("invalid json at byte %d: %w", typ.Offset, err)
+		default:
+			return nil, fmt.Errorf("invalid json: %w", err)
+		}
+	}
+	lists := make([]types.Watchlist, 0, len(in))
+	for _, l := range in {
+		wl := types.Watchlist{
+			Name:    l.Name,
+			Columns: append([]string(nil), l.Columns...),
+			Items:   make([]types.Item, 0, len(l.Items)),
+		}
+		for _, it := range l.Items {
+			fields := it.Fields
+			if fields == nil {
+				fields = map[string]any{}
+			}
+			wl.Items = append(wl.Items, types.Item{Sym: it.Sym, Name: it.Name, Fields: fields})
+		}
+		lists = append(lists, wl)
+	}
+	return lists, nil
+}
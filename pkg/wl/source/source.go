@@ -2,6 +2,7 @@ package source
 
 import (
 	"context"
+	"errors"
 
 	"github.com/komsit37/wl/pkg/wl/types"
 )
@@ -10,3 +11,25 @@ import (
 type Source interface {
 	Load(ctx context.Context, spec any) ([]types.Watchlist, error)
 }
+
+// LoadError reports that watchlists could not be loaded from a source: a
+// missing or unreadable file, bad YAML/CSV/JSON, a failed download or query.
+// Its message is the underlying error's.
+type LoadError struct{ Err error }
+
+func (e *LoadError) Error() string { return e.Err.Error() }
+func (e *LoadError) Unwrap() error { return e.Err }
+
+// Load calls src.Load, wrapping a failure in a *LoadError so callers can
+// tell it apart with errors.As.
+func Load(ctx context.Context, src Source, spec any) ([]types.Watchlist, error) {
+	lists, err := src.Load(ctx, spec)
+	if err != nil {
+		var le *LoadError
+		if !errors.As(err, &le) {
+			err = &LoadError{Err: err}
+		}
+		return nil, err
+	}
+	return lists, nil
+}
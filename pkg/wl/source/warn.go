@@ -0,0 +1,78 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// warnOut receives load warnings such as unknown YAML keys.
+var warnOut io.Writer = os.Stderr
+
+// Keys understood on the YAML root map and on group maps; anything else is
+// likely a typo (e.g. "column" or "items") and is reported.
+var (
+	yamlRootKeys  = []string{"name", "columns", "col_set", "watchlist"}
+	yamlGroupKeys = []string{"name", "columns", "col_set", "watchlist", "disabled"}
+)
+
+// warnf writes a warning about file to warnOut.
+func warnf(file, format string, args ...any) {
+	switch file {
+	case "":
+		file = "yaml"
+	case "-":
+		file = "stdin"
+	}
+	fmt.Fprintf(warnOut, "warning: %s: %s\n", file, fmt.Sprintf(format, args...))
+}
+
+// unknownKeys returns the sorted keys of m not in known.
+func unknownKeys(m map[string]any, known []string) []string {
+	var out []string
+	for k := range m {
+		found := false
+		for _, kk := range known {
+			if k == kk {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// checkGroupKeys warns about unknown keys on a group map.
+func checkGroupKeys(file string, g map[string]any, path []string) {
+	if unk := unknownKeys(g, yamlGroupKeys); len(unk) > 0 {
+		where := deriveName(path)
+		if where == "" {
+			where = "(unnamed)"
+		}
+		warnf(file, "group %s: ignoring unknown key(s) %s (known: %s)", where, strings.Join(unk, ", "), strings.Join(yamlGroupKeys, ", "))
+	}
+}
+
+// checkLeaf warns about an entry without a sym that holds a list, which is
+// usually a group whose 'watchlist' key is misspelled.
+func checkLeaf(file string, e map[string]any) {
+	if _, ok := e["sym"]; ok {
+		return
+	}
+	var lists []string
+	for k, v := range e {
+		if _, ok := v.([]any); ok {
+			lists = append(lists, k)
+		}
+	}
+	if len(lists) > 0 {
+		sort.Strings(lists)
+		warnf(file, "entry without sym has list key(s) %s; did you mean 'watchlist'?", strings.Join(lists, ", "))
+	}
+}
@@ -3,25 +3,42 @@ package source
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/komsit37/wl/pkg/wl/types"
 )
 
 // YAMLSource loads watchlists from a YAML file.
-type YAMLSource struct{}
+type YAMLSource struct {
+	// KeepEmpty keeps groups without any enabled item as empty lists
+	// instead of dropping them (wl validate reports them).
+	KeepEmpty bool
+}
 
-// Load expects spec to be a string filepath.
-func (YAMLSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error) { //nolint:revive // ctx reserved for future use
+// Load expects spec to be a string filepath; "-" reads YAML from stdin and an
+// http(s) URL is fetched.
+func (s YAMLSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error) {
 	path, ok := spec.(string)
 	if !ok {
 		return nil, fmt.Errorf("yaml source expects filepath string spec")
 	}
+	if path == "-" {
+		return loadYAMLStdin(s.KeepEmpty)
+	}
+	if isURL(path) {
+		return loadYAMLURL(ctx, path)
+	}
+	if hasGlobMeta(path) {
+		root, files, err := globFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		return loadYAMLFiles(root, files, s.KeepEmpty)
+	}
 	info, err := os.Stat(path)
 	if err != nil {
 		return nil, err
@@ -47,35 +64,7 @@ func (YAMLSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error)
 			return nil, err
 		}
 		sort.Strings(files)
-
-		var all []types.Watchlist
-		for _, full := range files {
-			data, err := os.ReadFile(full)
-			if err != nil {
-				return nil, err
-			}
-			lists, err := parseYAML(data)
-			if err != nil {
-				return nil, fmt.Errorf("%s: %w", full, err)
-			}
-			// Compute prefix from relative path (without extension), using forward slashes.
-			rel, err := filepath.Rel(path, full)
-			if err != nil {
-				rel = filepath.Base(full)
-			}
-			ext := filepath.Ext(rel)
-			prefix := strings.TrimSuffix(rel, ext)
-			prefix = filepath.ToSlash(prefix)
-			for i := range lists {
-				if strings.TrimSpace(lists[i].Name) == "" {
-					lists[i].Name = prefix
-				} else if prefix != "" {
-					lists[i].Name = prefix + "/" + lists[i].Name
-				}
-			}
-			all = append(all, lists...)
-		}
-		return all, nil
+		return loadYAMLFiles(path, files, s.KeepEmpty)
 	}
 
 	// Single file
@@ -83,7 +72,7 @@ func (YAMLSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error)
 	if err != nil {
 		return nil, err
 	}
-	lists, err := parseYAML(data)
+	lists, err := parseYAML(data, path, s.KeepEmpty)
 	if err != nil {
 		return nil, err
 	}
@@ -97,77 +86,137 @@ func (YAMLSource) Load(ctx context.Context, spec any) ([]types.Watchlist, error)
 	return lists, nil
 }
 
-// parseYAML parses the repo's YAML format into multiple watchlists.
-func parseYAML(data []byte) ([]types.Watchlist, error) {
-	var root any
-	if err := yaml.Unmarshal(data, &root); err != nil {
-		return nil, err
+// loadYAMLFiles loads and combines files found under root, prefixing list
+// names with each file's path relative to root (without extension).
+func loadYAMLFiles(root string, files []string, keepEmpty bool) ([]types.Watchlist, error) {
+	var all []types.Watchlist
+	for _, full := range files {
+		data, err := os.ReadFile(full)
+		if err != nil {
+			return nil, err
+		}
+		lists, err := parseYAML(data, full, keepEmpty)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", full, err)
+		}
+		// Compute prefix from relative path (without extension), using forward slashes.
+		rel, err := filepath.Rel(root, full)
+		if err != nil {
+			rel = filepath.Base(full)
+		}
+		ext := filepath.Ext(rel)
+		prefix := strings.TrimSuffix(rel, ext)
+		prefix = filepath.ToSlash(prefix)
+		for i := range lists {
+			if strings.TrimSpace(lists[i].Name) == "" {
+				lists[i].Name = prefix
+			} else if prefix != "" {
+				lists[i].Name = prefix + "/" + lists[i].Name
+			}
+		}
+		all = append(all, lists...)
 	}
+	return all, nil
+}
 
-	// Normalize maps with non-string keys to map[string]any
-	var norm func(v any) any
-	norm = func(v any) any {
-		switch m := v.(type) {
-		case map[any]any:
-			mm := make(map[string]any, len(m))
-			for k, val := range m {
-				mm[fmt.Sprint(k)] = norm(val)
-			}
-			return mm
-		case []any:
-			out := make([]any, 0, len(m))
-			for _, e := range m {
-				out = append(out, norm(e))
-			}
-			return out
-		default:
-			return v
+// loadYAMLStdin reads a single YAML document from stdin; unnamed lists are
+// called "stdin".
+func loadYAMLStdin(keepEmpty bool) ([]types.Watchlist, error) {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("read stdin: %w", err)
+	}
+	lists, err := parseYAML(data, "-", keepEmpty)
+	if err != nil {
+		return nil, fmt.Errorf("stdin: %w", err)
+	}
+	for i := range lists {
+		if strings.TrimSpace(lists[i].Name) == "" {
+			lists[i].Name = "stdin"
 		}
 	}
-	root = norm(root)
+	return lists, nil
+}
 
-	m, ok := root.(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("invalid yaml: expected map with 'watchlist'")
+// parseYAML parses the repo's YAML format into multiple watchlists. file is
+// where data came from and anchors relative include paths. With keepEmpty,
+// item lists holding neither items nor groups yield empty watchlists.
+func parseYAML(data []byte, file string, keepEmpty bool) ([]types.Watchlist, error) {
+	root, err := decodeYAML(data)
+	if err != nil {
+		return nil, err
 	}
 
-	var explicitCols []string
-	if v, ok := m["columns"]; ok && v != nil {
-		explicitCols = toStringSlice(v)
+	// The root is either a map with 'watchlist' or a bare sequence of items,
+	// which is read as one unnamed list.
+	var m map[string]any
+	var wlNode any
+	switch r := root.(type) {
+	case map[string]any:
+		m = r
+		wlNode = m["watchlist"]
+		if wlNode == nil {
+			if unk := unknownKeys(m, yamlRootKeys); len(unk) > 0 {
+				return nil, fmt.Errorf("invalid yaml: missing 'watchlist' (found unknown key(s) %s)", strings.Join(unk, ", "))
+			}
+			return nil, fmt.Errorf("invalid yaml: missing 'watchlist'")
+		}
+		if unk := unknownKeys(m, yamlRootKeys); len(unk) > 0 {
+			warnf(file, "ignoring unknown top-level key(s) %s (known: %s)", strings.Join(unk, ", "), strings.Join(yamlRootKeys, ", "))
+		}
+	case []any:
+		m = map[string]any{}
+		wlNode = r
+	default:
+		return nil, fmt.Errorf("invalid yaml: expected map with 'watchlist' or a list of items")
 	}
 
-	wlNode, ok := m["watchlist"]
-	if !ok || wlNode == nil {
-		return nil, fmt.Errorf("invalid yaml: missing 'watchlist'")
+	var chain []string
+	if file != "" && file != "-" && !isURL(file) {
+		if abs, err := filepath.Abs(file); err == nil {
+			chain = []string{abs}
+		}
+	}
+	wlNode, err = expandIncludes(wlNode, file, chain)
+	if err != nil {
+		return nil, err
 	}
 
 	// Traverse to produce lists.
 	var lists []types.Watchlist
 	// Accumulate path of group names.
-	var walk func(node any, path []string)
-	walk = func(node any, path []string) {
+	// Columns and column sets flow down to child groups unless a group
+	// declares its own.
+	var walk func(node any, path []string, gc groupCols)
+	walk = func(node any, path []string, gc groupCols) {
 		switch n := node.(type) {
 		case []any:
 			// Items or groups in a list; but only produce a list when encountering
 			// a named group or a plain list at root with leaf items.
 			// Detect if this list contains any leaf items; if so, make a list.
 			leafItems := make([]types.Item, 0)
+			hasGroups := false
 			for _, e := range n {
-				if isLeaf(e) {
+				if isLeaf(e) && !isDisabled(e) {
+					checkLeaf(file, e.(map[string]any))
 					it := toItem(e)
 					leafItems = append(leafItems, it)
 				}
+				if g, ok := e.(map[string]any); ok && g["watchlist"] != nil {
+					hasGroups = true
+				}
 			}
-			if len(leafItems) > 0 {
+			if len(leafItems) > 0 || (keepEmpty && !hasGroups) {
 				lists = append(lists, types.Watchlist{
 					Name:    deriveName(path),
-					Columns: append([]string(nil), explicitCols...),
+					Columns: append([]string(nil), gc.columns...),
 					Items:   leafItems,
+					ColSet:  append([]string(nil), gc.colSet...),
 				})
 			}
 			// Also traverse groups within this list.
 			for _, e := range n {
-				if g, ok := e.(map[string]any); ok {
+				if g, ok := e.(map[string]any); ok && !isDisabled(g) {
 					if child, ok := g["watchlist"]; ok {
 						var nextPath []string
 						if name, ok := g["name"].(string); ok && name != "" {
@@ -175,11 +224,15 @@ func parseYAML(data []byte) ([]types.Watchlist, error) {
 						} else {
 							nextPath = append([]string(nil), path...)
 						}
-						walk(child, nextPath)
+						checkGroupKeys(file, g, nextPath)
+						walk(child, nextPath, gc.inherit(g))
 					}
 				}
 			}
 		case map[string]any:
+			if isDisabled(n) {
+				return
+			}
 			if child, ok := n["watchlist"]; ok {
 				var nextPath []string
 				if name, ok := n["name"].(string); ok && name != "" {
@@ -187,24 +240,80 @@ func parseYAML(data []byte) ([]types.Watchlist, error) {
 				} else {
 					nextPath = append([]string(nil), path...)
 				}
-				walk(child, nextPath)
+				checkGroupKeys(file, n, nextPath)
+				walk(child, nextPath, gc.inherit(n))
 				return
 			}
 			// Single leaf at map level
 			if isLeaf(n) {
+				checkLeaf(file, n)
 				lists = append(lists, types.Watchlist{
 					Name:    deriveName(path),
-					Columns: append([]string(nil), explicitCols...),
+					Columns: append([]string(nil), gc.columns...),
 					Items:   []types.Item{toItem(n)},
+					ColSet:  append([]string(nil), gc.colSet...),
 				})
 			}
 		}
 	}
 
-	walk(wlNode, nil)
+	walk(wlNode, nil, groupCols{}.inherit(m))
+
+	// A top-level name names the file's unnamed list(s) instead of the
+	// filename fallback.
+	if name, ok := m["name"].(string); ok && strings.TrimSpace(name) != "" {
+		for i := range lists {
+			if strings.TrimSpace(lists[i].Name) == "" {
+				lists[i].Name = strings.TrimSpace(name)
+			}
+		}
+	}
 	return lists, nil
 }
 
+// groupCols carries the column settings a group passes down to its children.
+type groupCols struct {
+	columns []string
+	colSet  []string
+}
+
+// inherit returns the settings for group g: its own columns or col_set
+// replace the inherited ones, and its columns also clear an inherited col_set
+// so the closest declaration wins.
+func (c groupCols) inherit(g map[string]any) groupCols {
+	out := c
+	if v, ok := g["columns"]; ok && v != nil {
+		if cols := toStringSlice(v); len(cols) > 0 {
+			out.columns = cols
+			out.colSet = nil
+		}
+	}
+	out.colSet = groupColSet(g, out.colSet)
+	return out
+}
+
+// groupColSet returns the group's own col_set (a list or comma-separated
+// string), else the inherited one.
+func groupColSet(g map[string]any, inherited []string) []string {
+	switch v := g["col_set"].(type) {
+	case string:
+		var out []string
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				out = append(out, p)
+			}
+		}
+		if len(out) > 0 {
+			return out
+		}
+	case []any:
+		if out := toStringSlice(v); len(out) > 0 {
+			return out
+		}
+	}
+	return inherited
+}
+
 func toStringSlice(v any) []string {
 	if v == nil {
 		return nil
@@ -226,6 +335,21 @@ func toStringSlice(v any) []string {
 	}
 }
 
+// isDisabled reports whether a group or item sets disabled: true.
+func isDisabled(v any) bool {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	switch d := m["disabled"].(type) {
+	case bool:
+		return d
+	case string:
+		return strings.EqualFold(strings.TrimSpace(d), "true")
+	}
+	return false
+}
+
 func isLeaf(v any) bool {
 	m, ok := v.(map[string]any)
 	if !ok {
@@ -251,7 +375,7 @@ func toItem(v any) types.Item {
 		it.Fields["name"] = it.Name
 	}
 	for k, val := range m {
-		if k == "sym" || k == "name" || k == "watchlist" {
+		if k == "sym" || k == "name" || k == "watchlist" || k == "disabled" {
 			continue
 		}
 		it.Fields[k] = val
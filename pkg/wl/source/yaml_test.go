@@ -0,0 +1,216 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/komsit37/wl/pkg/wl/types"
+)
+
+// parse runs parseYAML on data, failing the test on error.
+func parse(t *testing.T, data string) []types.Watchlist {
+	t.Helper()
+	lists, err := parseYAML([]byte(data), "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lists
+}
+
+// listSyms returns each list's name mapped to its symbols.
+func listSyms(lists []types.Watchlist) map[string][]string {
+	out := map[string][]string{}
+	for _, l := range lists {
+		syms := []string{}
+		for _, it := range l.Items {
+			syms = append(syms, it.Sym)
+		}
+		out[l.Name] = syms
+	}
+	return out
+}
+
+func TestParseYAMLBareListRoot(t *testing.T) {
+	lists := parse(t, `
+- sym: AAPL
+  name: Apple
+- sym: MSFT
+  cost: 300
+`)
+	if len(lists) != 1 || lists[0].Name != "" {
+		t.Fatalf("lists = %+v, want one unnamed list", lists)
+	}
+	items := lists[0].Items
+	if len(items) != 2 || items[0].Sym != "AAPL" || items[0].Name != "Apple" || items[1].Fields["cost"] != 300 {
+		t.Errorf("items = %+v", items)
+	}
+	// the map form still works, and a scalar root is an error
+	if got := listSyms(parse(t, "watchlist:\n  - sym: AAPL\n")); !reflect.DeepEqual(got, map[string][]string{"": {"AAPL"}}) {
+		t.Errorf("map root = %v", got)
+	}
+	if _, err := parseYAML([]byte("just text"), "", false); err == nil {
+		t.Error("scalar root: want an error")
+	}
+
+	// loaded from a file, the list is named after it
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"tech.yaml": "- sym: AAPL\n- sym: MSFT\n"})
+	lists, err := YAMLSource{}.Load(context.Background(), filepath.Join(dir, "tech.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := listSyms(lists); !reflect.DeepEqual(got, map[string][]string{"tech": {"AAPL", "MSFT"}}) {
+		t.Errorf("tech.yaml = %v", got)
+	}
+}
+
+func TestYAMLTopLevelNameOverridesFilename(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.yaml": "name: Core\nwatchlist:\n  - sym: AAPL\n",
+		"b.yaml": "watchlist:\n  - sym: MSFT\n",
+		"c.yaml": "name: Core\nwatchlist:\n  - sym: KO\n  - name: banks\n    watchlist:\n      - sym: JPM\n",
+	})
+	for file, want := range map[string]map[string][]string{
+		"a.yaml": {"Core": {"AAPL"}},
+		"b.yaml": {"b": {"MSFT"}}, // no top-level name: the filename fallback
+		"c.yaml": {"Core": {"KO"}, "banks": {"JPM"}},
+	} {
+		lists, err := YAMLSource{}.Load(context.Background(), filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := listSyms(lists); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: lists %v, want %v", file, got, want)
+		}
+	}
+}
+
+func TestParseYAMLGroupColumns(t *testing.T) {
+	lists := parse(t, `
+columns: [sym, price]
+watchlist:
+  - sym: SPY
+  - name: tech
+    columns: [sym, pe]
+    watchlist:
+      - sym: AAPL
+      - name: chips
+        watchlist:
+          - sym: NVDA
+  - name: banks
+    watchlist:
+      - sym: JPM
+`)
+	want := map[string][]string{
+		"":           {"sym", "price"},
+		"tech":       {"sym", "pe"},
+		"tech/chips": {"sym", "pe"}, // inherited from the nearest group
+		"banks":      {"sym", "price"},
+	}
+	got := map[string][]string{}
+	for _, l := range lists {
+		got[l.Name] = l.Columns
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %v, want %v", got, want)
+	}
+
+	// a group's columns also replace an inherited col_set
+	lists = parse(t, "col_set: fundamentals\nwatchlist:\n  - name: g\n    columns: [sym]\n    watchlist:\n      - sym: AAPL\n")
+	if len(lists) != 1 || lists[0].ColSet != nil || !reflect.DeepEqual(lists[0].Columns, []string{"sym"}) {
+		t.Errorf("group columns over file col_set: %+v", lists)
+	}
+}
+
+// captureWarnings redirects load warnings into the returned buffer for the
+// rest of the test.
+func captureWarnings(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	prev := warnOut
+	warnOut = &buf
+	t.Cleanup(func() { warnOut = prev })
+	return &buf
+}
+
+func TestParseYAMLWarnsUnknownKeys(t *testing.T) {
+	warns := captureWarnings(t)
+	lists, err := parseYAML([]byte(`
+column: [sym, price]
+watchlist:
+  - name: tech
+    colums: [sym]
+    watchlist:
+      - sym: AAPL
+  - name: banks
+    items:
+      - sym: JPM
+`), "us.yaml", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "warning: us.yaml: ignoring unknown top-level key(s) column (known: name, columns, col_set, watchlist)\n" +
+		"warning: us.yaml: entry without sym has list key(s) items; did you mean 'watchlist'?\n" +
+		"warning: us.yaml: group tech: ignoring unknown key(s) colums (known: name, columns, col_set, watchlist, disabled)\n"
+	if got := warns.String(); got != want {
+		t.Errorf("warnings:\n%s\nwant:\n%s", got, want)
+	}
+	if len(lists) != 2 {
+		t.Errorf("lists = %+v", lists)
+	}
+
+	// known keys are quiet, and a root with only typos is an error
+	warns.Reset()
+	parse(t, "name: x\ncolumns: [sym]\ncol_set: price\nwatchlist:\n  - name: g\n    disabled: false\n    watchlist:\n      - sym: A\n")
+	if warns.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", warns)
+	}
+	if _, err := parseYAML([]byte("items:\n  - sym: A\n"), "", false); err == nil || !strings.Contains(err.Error(), "unknown key(s) items") {
+		t.Errorf("root without watchlist: %v", err)
+	}
+}
+
+func TestParseYAMLDisabled(t *testing.T) {
+	lists := parse(t, `
+watchlist:
+  - name: core
+    watchlist:
+      - sym: AAPL
+      - sym: KO
+        disabled: true
+      - sym: MSFT
+        disabled: "TRUE"
+  - name: seasonal
+    disabled: true
+    watchlist:
+      - sym: TGT
+      - name: nested
+        watchlist:
+          - sym: WMT
+  - name: live
+    disabled: false
+    watchlist:
+      - sym: JPM
+`)
+	want := map[string][]string{"core": {"AAPL"}, "live": {"JPM"}}
+	if got := listSyms(lists); !reflect.DeepEqual(got, want) {
+		t.Errorf("lists = %v, want %v", got, want)
+	}
+	if _, ok := lists[0].Items[0].Fields["disabled"]; ok {
+		t.Error("disabled leaked into item fields")
+	}
+
+	// a list whose items are all disabled is dropped, or kept empty with keepEmpty
+	data := []byte("watchlist:\n  - name: off\n    watchlist:\n      - sym: A\n        disabled: true\n")
+	if lists, _ := parseYAML(data, "", false); len(lists) != 0 {
+		t.Errorf("all-disabled list kept: %+v", lists)
+	}
+	if lists, _ := parseYAML(data, "", true); len(lists) != 1 || lists[0].Name != "off" || len(lists[0].Items) != 0 {
+		t.Errorf("keepEmpty: %+v", lists)
+	}
+}
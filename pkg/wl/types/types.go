@@ -5,6 +5,9 @@ type Watchlist struct {
 	Name    string
 	Columns []string
 	Items   []Item
+	// ColSet names column sets declared for this list's group in YAML; when
+	// set they replace Columns for this list.
+	ColSet []string
 }
 
 // Item represents a symbol entry and arbitrary fields.